@@ -18,9 +18,11 @@ func exemplo1() {
 	client := opensearchmanager.NewClient("http://localhost:9200", "admin", "adminpassword")
 
 	// Exemplo: Limpeza de índices antigos
-	if err := client.CleanupByAge(ctx, "logs-", 30); err != nil {
+	deleted, err := client.CleanupByAge(ctx, "logs-", 30)
+	if err != nil {
 		log.Fatalf("Failed to cleanup old indices: %v", err)
 	}
+	fmt.Printf("Deleted indices: %v\n", deleted)
 
 	// Exemplo: Rollover de índice
 	conditions := map[string]interface{}{
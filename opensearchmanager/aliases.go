@@ -0,0 +1,178 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EnsureRolloverAlias garante que o alias exista e aponte para um índice de
+// escrita, criando initialIndex (ex.: "logs-000001") quando necessário.
+// Pode ser chamado repetidamente sem efeitos colaterais.
+func (c *Client) EnsureRolloverAlias(ctx context.Context, alias, initialIndex string, settings map[string]interface{}) error {
+	var current map[string]struct {
+		Aliases map[string]struct {
+			IsWriteIndex *bool `json:"is_write_index"`
+		} `json:"aliases"`
+	}
+
+	path := fmt.Sprintf("/_alias/%s", alias)
+	err := c.call(ctx, "GET", path, nil, &current)
+	if err != nil && !IsNotFound(err) {
+		return fmt.Errorf("failed to get alias: %w", err)
+	}
+
+	if err == nil && len(current) > 0 {
+		for _, idx := range current {
+			if a, ok := idx.Aliases[alias]; ok && a.IsWriteIndex != nil && *a.IsWriteIndex {
+				return nil
+			}
+		}
+		// Com um único índice o alias já é gravável implicitamente
+		if len(current) == 1 {
+			return nil
+		}
+		return fmt.Errorf("alias %s points to %d indices but none is the write index", alias, len(current))
+	}
+
+	err = c.CreateIndex(ctx, initialIndex, CreateIndexRequest{
+		Settings: settings,
+		Aliases: map[string]interface{}{
+			alias: map[string]interface{}{"is_write_index": true},
+		},
+	})
+	if err == nil {
+		return nil
+	}
+	if !IsIndexAlreadyExists(err) {
+		return err
+	}
+
+	// O índice inicial já existe sem o alias: apenas associa o alias de escrita
+	return c.ManageAliases(ctx, []AliasAction{
+		{
+			Add: map[string]interface{}{
+				"index":          initialIndex,
+				"alias":          alias,
+				"is_write_index": true,
+			},
+		},
+	})
+}
+
+// GetAlias retorna os índices associados ao alias; se o alias não existir
+// retorna uma lista vazia sem erro
+func (c *Client) GetAlias(ctx context.Context, alias string) ([]string, error) {
+	var payload map[string]interface{}
+
+	path := fmt.Sprintf("/_alias/%s", alias)
+	if err := c.call(ctx, "GET", path, nil, &payload); err != nil {
+		if IsNotFound(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to get alias: %w", err)
+	}
+
+	indices := make([]string, 0, len(payload))
+	for index := range payload {
+		indices = append(indices, index)
+	}
+	sort.Strings(indices)
+
+	return indices, nil
+}
+
+// ListAliases retorna o mapa alias -> índices de todo o cluster, com os
+// índices de cada alias em ordem alfabética. Aliases de sistema (e aliases
+// de índices de sistema) só são incluídos com IncludeHidden.
+func (c *Client) ListAliases(ctx context.Context) (map[string][]string, error) {
+	var payload map[string]struct {
+		Aliases map[string]interface{} `json:"aliases"`
+	}
+
+	if err := c.call(ctx, "GET", "/_alias", nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to list aliases: %w", err)
+	}
+
+	result := make(map[string][]string)
+	for index, entry := range payload {
+		for alias := range entry.Aliases {
+			if !c.IncludeHidden && (strings.HasPrefix(alias, ".") || strings.HasPrefix(index, ".")) {
+				continue
+			}
+			result[alias] = append(result[alias], index)
+		}
+	}
+	for _, indices := range result {
+		sort.Strings(indices)
+	}
+
+	return result, nil
+}
+
+// AliasExists verifica a existência do alias com uma requisição HEAD
+func (c *Client) AliasExists(ctx context.Context, alias string) (bool, error) {
+	exists, err := c.exists(ctx, fmt.Sprintf("/_alias/%s", alias))
+	if err != nil {
+		return false, fmt.Errorf("failed to check alias: %w", err)
+	}
+	return exists, nil
+}
+
+// SwapAlias move o alias de fromIndex para toIndex de forma atômica, em uma
+// única chamada _aliases, sem janela em que o alias fique sem índice
+func (c *Client) SwapAlias(ctx context.Context, alias, fromIndex, toIndex string) error {
+	if fromIndex == toIndex {
+		return fmt.Errorf("cannot swap alias %s: source and target index are both %s", alias, fromIndex)
+	}
+
+	return c.ManageAliases(ctx, []AliasAction{
+		{
+			Remove: map[string]interface{}{
+				"index": fromIndex,
+				"alias": alias,
+			},
+		},
+		{
+			Add: map[string]interface{}{
+				"index": toIndex,
+				"alias": alias,
+			},
+		},
+	})
+}
+
+// AliasOptions define propriedades opcionais de um alias
+type AliasOptions struct {
+	Filter        map[string]interface{}
+	Routing       string
+	IndexRouting  string
+	SearchRouting string
+	IsWriteIndex  *bool
+}
+
+// AddAlias monta uma ação "add" tipada, útil para aliases filtrados por tenant
+func AddAlias(index, alias string, opts AliasOptions) AliasAction {
+	add := map[string]interface{}{
+		"index": index,
+		"alias": alias,
+	}
+	if opts.Filter != nil {
+		add["filter"] = opts.Filter
+	}
+	if opts.Routing != "" {
+		add["routing"] = opts.Routing
+	}
+	if opts.IndexRouting != "" {
+		add["index_routing"] = opts.IndexRouting
+	}
+	if opts.SearchRouting != "" {
+		add["search_routing"] = opts.SearchRouting
+	}
+	if opts.IsWriteIndex != nil {
+		add["is_write_index"] = *opts.IsWriteIndex
+	}
+
+	return AliasAction{Add: add}
+}
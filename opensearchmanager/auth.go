@@ -0,0 +1,263 @@
+package opensearchmanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Authenticator assina/autentica uma requisição antes do envio
+type Authenticator interface {
+	Sign(req *http.Request) error
+}
+
+// BasicAuthenticator autentica com usuário e senha (HTTP Basic)
+type BasicAuthenticator struct {
+	Username string
+	Password string
+}
+
+// Sign aplica o cabeçalho de Basic Auth
+func (a BasicAuthenticator) Sign(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
+
+// TokenRefresher é implementado por autenticadores capazes de renovar suas
+// credenciais; o cliente chama RefreshToken ao receber um 401 e reenvia a
+// requisição uma única vez
+type TokenRefresher interface {
+	RefreshToken(ctx context.Context) error
+}
+
+// BearerAuthenticator autentica com um token Bearer (OpenID/JWT)
+type BearerAuthenticator struct {
+	mu      sync.RWMutex
+	token   string
+	refresh func(ctx context.Context) (string, error)
+}
+
+// NewBearerAuthenticator cria um autenticador Bearer. refresh é opcional e,
+// quando informado, é usado para obter um novo token após um 401.
+func NewBearerAuthenticator(token string, refresh func(ctx context.Context) (string, error)) *BearerAuthenticator {
+	return &BearerAuthenticator{token: token, refresh: refresh}
+}
+
+// Sign aplica o cabeçalho Authorization: Bearer
+func (a *BearerAuthenticator) Sign(req *http.Request) error {
+	a.mu.RLock()
+	token := a.token
+	a.mu.RUnlock()
+
+	if token == "" {
+		return fmt.Errorf("bearer token is empty")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// RefreshToken obtém um novo token pelo callback configurado
+func (a *BearerAuthenticator) RefreshToken(ctx context.Context) error {
+	if a.refresh == nil {
+		return fmt.Errorf("no token refresh callback configured")
+	}
+
+	token, err := a.refresh(ctx)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.token = token
+	a.mu.Unlock()
+	return nil
+}
+
+// AWSV4Authenticator assina requisições com AWS Signature Version 4
+// para uso com o Amazon OpenSearch Service
+type AWSV4Authenticator struct {
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	Region       string
+	Service      string // "es" para domínios gerenciados, "aoss" para serverless
+}
+
+// NewAWSV4Authenticator cria um autenticador SigV4
+func NewAWSV4Authenticator(accessKey, secretKey, region, service string) *AWSV4Authenticator {
+	if service == "" {
+		service = "es"
+	}
+	return &AWSV4Authenticator{
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		Region:    region,
+		Service:   service,
+	}
+}
+
+// Sign calcula a assinatura SigV4 e define os cabeçalhos de autorização
+func (a *AWSV4Authenticator) Sign(req *http.Request) error {
+	payload, err := readRequestBody(req)
+	if err != nil {
+		return fmt.Errorf("failed to read request body for signing: %w", err)
+	}
+
+	t := time.Now().UTC()
+	amzDate := t.Format("20060102T150405Z")
+	dateStamp := t.Format("20060102")
+
+	payloadHash := sha256Hex(payload)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if a.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", a.SessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{
+		"host":                 host,
+		"x-amz-date":           amzDate,
+		"x-amz-content-sha256": payloadHash,
+	}
+	if a.SessionToken != "" {
+		headers["x-amz-security-token"] = a.SessionToken
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		awsEscapePath(req.URL.EscapedPath()),
+		awsCanonicalQuery(req),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{dateStamp, a.Region, a.Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+a.SecretKey), dateStamp)
+	key = hmacSHA256(key, a.Region)
+	key = hmacSHA256(key, a.Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		a.AccessKey, scope, signedHeaders, signature,
+	))
+	return nil
+}
+
+// readRequestBody lê o corpo sem consumi-lo, usando GetBody quando disponível
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	payload, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(payload))
+	return payload, nil
+}
+
+// awsCanonicalQuery ordena e codifica a query string no formato SigV4
+func awsCanonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, awsEscape(k)+"="+awsEscape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsEscapePath codifica novamente cada segmento do caminho, como exigido
+// pelo SigV4 para serviços diferentes do S3
+func awsEscapePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = awsEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// awsEscape aplica a codificação RFC 3986 preservando apenas caracteres não reservados
+func awsEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,133 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DeleteOptions controla exclusões de muitos índices
+type DeleteOptions struct {
+	// BatchSize é o máximo de índices por requisição DELETE (zero: todos em uma)
+	BatchSize int
+	// Concurrency é o máximo de requisições simultâneas (zero: 1)
+	Concurrency int
+	// ContinueOnError envia uma requisição por índice, de modo que um índice
+	// inválido não derrube os demais; as falhas vêm em um *BatchError
+	ContinueOnError bool
+}
+
+// CloseOptions controla fechamentos de muitos índices
+type CloseOptions struct {
+	// ContinueOnError fecha um índice por requisição em vez de um único
+	// caminho com todos; as falhas vêm em um *BatchError
+	ContinueOnError bool
+}
+
+// BatchFailure descreve um lote que falhou
+type BatchFailure struct {
+	Indices []string
+	Err     error
+}
+
+// BatchError agrega as falhas de uma operação em lotes
+type BatchError struct {
+	Failures []BatchFailure
+	// Succeeded lista os índices processados com sucesso
+	Succeeded []string
+}
+
+// Error implementa a interface error
+func (e *BatchError) Error() string {
+	var msgs []string
+	for _, f := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("[%s]: %v", strings.Join(f.Indices, ","), f.Err))
+	}
+	return fmt.Sprintf("%d batch(es) failed: %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+// Unwrap expõe os erros individuais para errors.Is/errors.As
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// DeleteIndexNames exclui os índices informados em lotes de opts.BatchSize,
+// com até opts.Concurrency requisições simultâneas. Lotes com falha são
+// reportados em um *BatchError sem interromper os demais.
+func (c *Client) DeleteIndexNames(ctx context.Context, names []string, opts DeleteOptions, reqOpts ...RequestOption) error {
+	if err := c.checkProtectedNames(names); err != nil {
+		return err
+	}
+	if c.DryRun || len(names) == 0 {
+		return nil
+	}
+
+	batchSize := opts.BatchSize
+	if opts.ContinueOnError {
+		batchSize = 1
+	}
+	batches := chunkNames(names, batchSize)
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		failures  []BatchFailure
+		succeeded []string
+		sem       = make(chan struct{}, concurrency)
+	)
+
+	for _, batch := range batches {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(batch []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			path := withRequestOptions(fmt.Sprintf("/%s", strings.Join(batch, ",")), reqOpts)
+			err := c.callAcknowledged(ctx, "DELETE", path, nil)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures = append(failures, BatchFailure{Indices: batch, Err: err})
+			} else {
+				succeeded = append(succeeded, batch...)
+			}
+		}(batch)
+	}
+	wg.Wait()
+
+	if len(failures) == 0 {
+		return nil
+	}
+	if len(batches) == 1 && !opts.ContinueOnError {
+		return fmt.Errorf("failed to delete indices: %w", failures[0].Err)
+	}
+	return fmt.Errorf("failed to delete indices: %w", &BatchError{Failures: failures, Succeeded: succeeded})
+}
+
+// chunkNames divide a lista em lotes de até size elementos (size <= 0: um lote)
+func chunkNames(names []string, size int) [][]string {
+	if size <= 0 || size >= len(names) {
+		return [][]string{names}
+	}
+
+	var batches [][]string
+	for start := 0; start < len(names); start += size {
+		end := start + size
+		if end > len(names) {
+			end = len(names)
+		}
+		batches = append(batches, names[start:end])
+	}
+	return batches
+}
@@ -0,0 +1,134 @@
+package opensearchmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// BulkDoc representa uma operação do _bulk
+type BulkDoc struct {
+	// Action é "index", "create", "update" ou "delete" (padrão: "index")
+	Action string
+	// ID é opcional para index; obrigatório para create com ID fixo, update e delete
+	ID string
+	// Source é o documento; em update é enviado como {"doc": Source}
+	Source interface{}
+}
+
+// BulkItemError representa o erro de um item do _bulk
+type BulkItemError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// BulkItemResult representa o resultado de um item do _bulk
+type BulkItemResult struct {
+	Action  string
+	Index   string
+	ID      string
+	Status  int
+	Result  string
+	Version int64
+	Error   *BulkItemError
+}
+
+// BulkResponse representa a resposta do _bulk
+type BulkResponse struct {
+	Took   int64
+	Errors bool
+	Items  []BulkItemResult
+	// Failed é o número de itens com erro, para que o chamador possa reenviá-los
+	Failed int
+}
+
+// Bulk envia as operações em uma única requisição NDJSON para /_bulk e
+// retorna o resultado por item na mesma ordem de docs
+func (c *Client) Bulk(ctx context.Context, index string, docs []BulkDoc) (*BulkResponse, error) {
+	if len(docs) == 0 {
+		return &BulkResponse{}, nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i, doc := range docs {
+		action := doc.Action
+		if action == "" {
+			action = "index"
+		}
+
+		meta := map[string]interface{}{"_index": index}
+		if doc.ID != "" {
+			meta["_id"] = doc.ID
+		}
+
+		switch action {
+		case "index", "create":
+			if err := enc.Encode(map[string]interface{}{action: meta}); err != nil {
+				return nil, fmt.Errorf("failed to encode bulk item %d: %w", i, err)
+			}
+			if err := enc.Encode(doc.Source); err != nil {
+				return nil, fmt.Errorf("failed to encode bulk item %d: %w", i, err)
+			}
+		case "update":
+			if doc.ID == "" {
+				return nil, fmt.Errorf("bulk item %d: update requires an ID", i)
+			}
+			if err := enc.Encode(map[string]interface{}{action: meta}); err != nil {
+				return nil, fmt.Errorf("failed to encode bulk item %d: %w", i, err)
+			}
+			if err := enc.Encode(map[string]interface{}{"doc": doc.Source}); err != nil {
+				return nil, fmt.Errorf("failed to encode bulk item %d: %w", i, err)
+			}
+		case "delete":
+			if doc.ID == "" {
+				return nil, fmt.Errorf("bulk item %d: delete requires an ID", i)
+			}
+			if err := enc.Encode(map[string]interface{}{action: meta}); err != nil {
+				return nil, fmt.Errorf("failed to encode bulk item %d: %w", i, err)
+			}
+		default:
+			return nil, fmt.Errorf("bulk item %d: unsupported action %q", i, action)
+		}
+	}
+
+	var payload struct {
+		Took   int64                                `json:"took"`
+		Errors bool                                 `json:"errors"`
+		Items  []map[string]bulkResponseItemPayload `json:"items"`
+	}
+	if err := c.call(ctx, "POST", "/_bulk", &buf, &payload); err != nil {
+		return nil, fmt.Errorf("failed to execute bulk: %w", err)
+	}
+
+	result := &BulkResponse{Took: payload.Took, Errors: payload.Errors}
+	for _, item := range payload.Items {
+		for action, r := range item {
+			result.Items = append(result.Items, BulkItemResult{
+				Action:  action,
+				Index:   r.Index,
+				ID:      r.ID,
+				Status:  r.Status,
+				Result:  r.Result,
+				Version: r.Version,
+				Error:   r.Error,
+			})
+			if r.Error != nil {
+				result.Failed++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// bulkResponseItemPayload representa o corpo de cada item na resposta do _bulk
+type bulkResponseItemPayload struct {
+	Index   string         `json:"_index"`
+	ID      string         `json:"_id"`
+	Version int64          `json:"_version"`
+	Result  string         `json:"result"`
+	Status  int            `json:"status"`
+	Error   *BulkItemError `json:"error"`
+}
@@ -0,0 +1,83 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager"
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+func TestBulk(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("POST", "/_bulk", 200, `{"took":7,"errors":true,"items":[`+
+		`{"index":{"_index":"logs-1","_id":"a","_version":1,"result":"created","status":201}},`+
+		`{"update":{"_index":"logs-1","_id":"b","status":409,"error":{"type":"version_conflict_engine_exception","reason":"conflict"}}},`+
+		`{"delete":{"_index":"logs-1","_id":"c","_version":3,"result":"deleted","status":200}}]}`)
+
+	docs := []opensearchmanager.BulkDoc{
+		{ID: "a", Source: map[string]interface{}{"msg": "one"}},
+		{Action: "update", ID: "b", Source: map[string]interface{}{"msg": "two"}},
+		{Action: "delete", ID: "c"},
+	}
+	resp, err := srv.Client().Bulk(context.Background(), "logs-1", docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(srv.Requests()[0].Body), "\n"), "\n")
+	want := []string{
+		`{"index":{"_id":"a","_index":"logs-1"}}`,
+		`{"msg":"one"}`,
+		`{"update":{"_id":"b","_index":"logs-1"}}`,
+		`{"doc":{"msg":"two"}}`,
+		`{"delete":{"_id":"c","_index":"logs-1"}}`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d NDJSON lines, want %d: %q", len(lines), len(want), lines)
+	}
+	for i := range want {
+		assertJSON(t, []byte(lines[i]), want[i])
+	}
+
+	if !resp.Errors || resp.Failed != 1 || resp.Took != 7 || len(resp.Items) != 3 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if item := resp.Items[0]; item.Action != "index" || item.Status != 201 || item.Version != 1 || item.Error != nil {
+		t.Errorf("unexpected item 0: %+v", item)
+	}
+	if item := resp.Items[1]; item.Action != "update" || item.ID != "b" || item.Status != 409 ||
+		item.Error == nil || item.Error.Type != "version_conflict_engine_exception" {
+		t.Errorf("unexpected item 1: %+v", item)
+	}
+	if item := resp.Items[2]; item.Action != "delete" || item.Result != "deleted" {
+		t.Errorf("unexpected item 2: %+v", item)
+	}
+}
+
+func TestBulkInvalidItems(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  opensearchmanager.BulkDoc
+	}{
+		{"update without id", opensearchmanager.BulkDoc{Action: "update", Source: map[string]interface{}{}}},
+		{"delete without id", opensearchmanager.BulkDoc{Action: "delete"}},
+		{"unknown action", opensearchmanager.BulkDoc{Action: "upsert", ID: "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+
+			if _, err := srv.Client().Bulk(context.Background(), "logs-1", []opensearchmanager.BulkDoc{tt.doc}); err == nil {
+				t.Fatal("expected error")
+			}
+			if n := len(srv.Requests()); n != 0 {
+				t.Errorf("sent %d requests, want none", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package opensearchmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DeleteByQueryResult representa o resultado de _delete_by_query
+type DeleteByQueryResult struct {
+	Took             int64             `json:"took"`
+	TimedOut         bool              `json:"timed_out"`
+	Total            int64             `json:"total"`
+	Deleted          int64             `json:"deleted"`
+	VersionConflicts int64             `json:"version_conflicts"`
+	Failures         []json.RawMessage `json:"failures"`
+}
+
+// DeleteByQuery remove os documentos do índice que satisfazem a query,
+// para retenção no nível de documento (ex.: eventos antigos em um índice
+// contínuo). Índices protegidos por ProtectedPatterns são recusados. Ao
+// contrário de UpdateByQuery, query nil é recusada: para remover todos os
+// documentos passe {"match_all": {}} explicitamente.
+func (c *Client) DeleteByQuery(ctx context.Context, index string, query map[string]interface{}) (*DeleteByQueryResult, error) {
+	if err := c.checkDeleteByQuery(index, query); err != nil {
+		return nil, err
+	}
+
+	var result DeleteByQueryResult
+	path := fmt.Sprintf("/%s/_delete_by_query", index)
+	if err := c.call(ctx, "POST", path, map[string]interface{}{"query": query}, &result); err != nil {
+		return nil, fmt.Errorf("failed to delete by query: %w", err)
+	}
+
+	return &result, nil
+}
+
+// DeleteByQueryAsync dispara a exclusão em segundo plano
+// (wait_for_completion=false) e retorna o ID da task para GetTask
+func (c *Client) DeleteByQueryAsync(ctx context.Context, index string, query map[string]interface{}) (string, error) {
+	if err := c.checkDeleteByQuery(index, query); err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Task string `json:"task"`
+	}
+
+	path := fmt.Sprintf("/%s/_delete_by_query?wait_for_completion=false", index)
+	if err := c.call(ctx, "POST", path, map[string]interface{}{"query": query}, &result); err != nil {
+		return "", fmt.Errorf("failed to start delete by query: %w", err)
+	}
+	if result.Task == "" {
+		return "", fmt.Errorf("delete by query response did not include a task id")
+	}
+
+	return result.Task, nil
+}
+
+// checkDeleteByQuery valida a exclusão antes de qualquer requisição
+func (c *Client) checkDeleteByQuery(index string, query map[string]interface{}) error {
+	if query == nil {
+		return fmt.Errorf("delete by query requires a query; use {\"match_all\": {}} to delete every document")
+	}
+	return c.checkProtected(index)
+}
+
+// UpdateByQueryResult representa o resultado de _update_by_query
+type UpdateByQueryResult struct {
+	Took             int64             `json:"took"`
+	TimedOut         bool              `json:"timed_out"`
+	Total            int64             `json:"total"`
+	Updated          int64             `json:"updated"`
+	Noops            int64             `json:"noops"`
+	VersionConflicts int64             `json:"version_conflicts"`
+	Failures         []json.RawMessage `json:"failures"`
+}
+
+// UpdateByQuery aplica o script aos documentos que satisfazem a query, sem
+// reindexar (ex.: preencher um campo novo). Use WithRequestsPerSecond para
+// limitar a vazão; query nil atinge todos os documentos.
+func (c *Client) UpdateByQuery(ctx context.Context, index string, query, script map[string]interface{}, opts ...RequestOption) (*UpdateByQueryResult, error) {
+	body := map[string]interface{}{}
+	if query != nil {
+		body["query"] = query
+	}
+	if script != nil {
+		body["script"] = script
+	}
+
+	var result UpdateByQueryResult
+	path := withRequestOptions(fmt.Sprintf("/%s/_update_by_query", index), opts)
+	if err := c.call(ctx, "POST", path, body, &result); err != nil {
+		return nil, fmt.Errorf("failed to update by query: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,43 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+func TestDeleteByQueryNilQuery(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	if _, err := client.DeleteByQuery(context.Background(), "logs-1", nil); err == nil || !strings.Contains(err.Error(), "requires a query") {
+		t.Errorf("DeleteByQuery: got %v, want query required error", err)
+	}
+	if _, err := client.DeleteByQueryAsync(context.Background(), "logs-1", nil); err == nil || !strings.Contains(err.Error(), "requires a query") {
+		t.Errorf("DeleteByQueryAsync: got %v, want query required error", err)
+	}
+	if reqs := srv.Requests(); len(reqs) != 0 {
+		t.Errorf("nil query must not reach the cluster: %+v", reqs)
+	}
+}
+
+func TestDeleteByQuery(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("POST", "/logs-1/_delete_by_query", 200, `{"took":5,"total":2,"deleted":2,"version_conflicts":0,"failures":[]}`)
+
+	query := map[string]interface{}{"range": map[string]interface{}{"@timestamp": map[string]interface{}{"lt": "now-30d"}}}
+	result, err := srv.Client().DeleteByQuery(context.Background(), "logs-1", query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Deleted != 2 || result.Total != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if body := string(srv.Requests()[0].Body); body != `{"query":{"range":{"@timestamp":{"lt":"now-30d"}}}}` {
+		t.Errorf("unexpected body: %s", body)
+	}
+}
@@ -0,0 +1,77 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager"
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+// cancelAfter cancela o contexto assim que uma requisição cujo caminho começa
+// com path é concluída
+type cancelAfter struct {
+	path   string
+	cancel context.CancelFunc
+}
+
+func (l cancelAfter) Log(_ context.Context, event opensearchmanager.LogEvent) {
+	if strings.HasPrefix(event.Path, l.path) {
+		l.cancel()
+	}
+}
+
+func TestCleanupByAgeCancelled(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/_cat/indices", 200, catBody(catRow("logs-old", "open", "1mb", 400)))
+	srv.Handle("DELETE", "/logs-old", 200, `{"acknowledged":true}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := srv.Client()
+	client.Logger = cancelAfter{path: "/_cat/indices", cancel: cancel}
+
+	if _, err := client.CleanupByAge(ctx, "logs-", 30); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+	if reqs := writes(srv); len(reqs) != 0 {
+		t.Errorf("requests after cancellation: %v", reqs)
+	}
+}
+
+func TestShrinkIndexCancelled(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/logs-1/_settings", 200, sourceSettings)
+	srv.Handle("POST", "/logs-1/_close", 200, `{"acknowledged":true}`)
+	srv.Handle("POST", "/logs-1/_shrink/logs-1-shrunk", 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+	srv.Handle("DELETE", "/logs-1-shrunk", 200, `{"acknowledged":true}`)
+	srv.Handle("POST", "/logs-1/_open", 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+	srv.Handle("PUT", "/logs-1/_settings", 200, `{"acknowledged":true}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := srv.Client()
+	client.Logger = cancelAfter{path: "/logs-1/_shrink/logs-1-shrunk", cancel: cancel}
+
+	err := client.ShrinkIndex(ctx, "logs-1", "logs-1-shrunk", map[string]interface{}{"number_of_shards": 2})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+
+	// Nenhuma etapa do shrink após o cancelamento; apenas o rollback
+	want := []string{
+		"POST /logs-1/_close",
+		"POST /logs-1/_shrink/logs-1-shrunk",
+		"DELETE /logs-1-shrunk",
+		"POST /logs-1/_open",
+		"PUT /logs-1/_settings",
+	}
+	if got := writes(srv); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests %v, want %v", got, want)
+	}
+}
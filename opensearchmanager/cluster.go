@@ -0,0 +1,163 @@
+package opensearchmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Ping verifica a conectividade e as credenciais com uma chamada barata à raiz
+// da API. Falhas de autenticação retornam *APIError (ver IsUnauthorized e
+// IsForbidden); falhas de rede retornam um erro de conexão.
+func (c *Client) Ping(ctx context.Context) error {
+	err := c.call(ctx, "GET", "/", nil, nil)
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return err
+	}
+	return fmt.Errorf("failed to connect to %s: %w", c.Endpoint, err)
+}
+
+// ClusterHealth representa o estado de saúde do cluster
+type ClusterHealth struct {
+	ClusterName         string  `json:"cluster_name"`
+	Status              string  `json:"status"`
+	TimedOut            bool    `json:"timed_out"`
+	NumberOfNodes       int     `json:"number_of_nodes"`
+	NumberOfDataNodes   int     `json:"number_of_data_nodes"`
+	ActivePrimaryShards int     `json:"active_primary_shards"`
+	ActiveShards        int     `json:"active_shards"`
+	RelocatingShards    int     `json:"relocating_shards"`
+	InitializingShards  int     `json:"initializing_shards"`
+	UnassignedShards    int     `json:"unassigned_shards"`
+	ActiveShardsPercent float64 `json:"active_shards_percent_as_number"`
+}
+
+// HealthOptions define parâmetros opcionais da consulta de saúde
+type HealthOptions struct {
+	// Index restringe a consulta a um índice ou padrão
+	Index string
+	// WaitForStatus bloqueia até o status informado (green, yellow, red)
+	WaitForStatus string
+	// Timeout limita a espera do servidor por WaitForStatus
+	Timeout time.Duration
+	// Level define o detalhamento da resposta (cluster, indices, shards)
+	Level string
+}
+
+// ClusterHealth retorna a saúde atual do cluster
+func (c *Client) ClusterHealth(ctx context.Context) (*ClusterHealth, error) {
+	return c.ClusterHealthWithOptions(ctx, HealthOptions{})
+}
+
+// ClusterHealthWithOptions retorna a saúde do cluster, podendo aguardar
+// um status específico. Se o prazo expirar antes do status ser atingido,
+// a saúde atual é retornada junto com um erro.
+func (c *Client) ClusterHealthWithOptions(ctx context.Context, opts HealthOptions) (*ClusterHealth, error) {
+	path := "/_cluster/health"
+	if opts.Index != "" {
+		path += "/" + opts.Index
+	}
+
+	params := url.Values{}
+	if opts.WaitForStatus != "" {
+		params.Set("wait_for_status", opts.WaitForStatus)
+	}
+	if opts.Timeout > 0 {
+		params.Set("timeout", formatDuration(opts.Timeout))
+	}
+	if opts.Level != "" {
+		params.Set("level", opts.Level)
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var health ClusterHealth
+	err := c.call(ctx, "GET", path, nil, &health)
+	if err == nil {
+		return &health, nil
+	}
+
+	// O OpenSearch responde 408 com o corpo completo quando o status esperado
+	// não é atingido dentro do timeout
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusRequestTimeout {
+		if jsonErr := json.Unmarshal(apiErr.Raw, &health); jsonErr == nil {
+			return &health, fmt.Errorf("cluster health did not reach %s before timeout (current: %s)", opts.WaitForStatus, health.Status)
+		}
+	}
+	return nil, fmt.Errorf("failed to get cluster health: %w", err)
+}
+
+// formatDuration converte uma duração para o formato de tempo do OpenSearch (ex.: "30s")
+func formatDuration(d time.Duration) string {
+	if d%time.Second == 0 {
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+	return fmt.Sprintf("%dms", d/time.Millisecond)
+}
+
+// WaitForGreen bloqueia até o índice atingir status green ou o timeout expirar
+func (c *Client) WaitForGreen(ctx context.Context, index string, timeout time.Duration) error {
+	health, err := c.ClusterHealthWithOptions(ctx, HealthOptions{
+		Index:         index,
+		WaitForStatus: "green",
+		Timeout:       timeout,
+		Level:         "indices",
+	})
+	if err != nil {
+		return fmt.Errorf("index %s is not green: %w", index, err)
+	}
+	if health.TimedOut || health.Status != "green" {
+		return fmt.Errorf("index %s is not green (status: %s)", index, health.Status)
+	}
+	return nil
+}
+
+// GetClusterSettings retorna as configurações do cluster em formato plano,
+// separadas nas chaves "persistent" e "transient"
+// (ex.: result["transient"]["cluster.routing.allocation.enable"])
+func (c *Client) GetClusterSettings(ctx context.Context) (map[string]interface{}, error) {
+	var payload map[string]interface{}
+	if err := c.call(ctx, "GET", "/_cluster/settings?flat_settings=true", nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to get cluster settings: %w", err)
+	}
+	return payload, nil
+}
+
+// UpdateClusterSettings altera configurações do cluster. Configurações
+// transient se perdem em um restart completo do cluster; persistent
+// sobrevivem. Um valor nil remove a configuração, voltando ao padrão.
+//
+// Ex.: desativar a alocação de shards antes de um rolling restart:
+//
+//	client.UpdateClusterSettings(ctx, nil, map[string]interface{}{
+//		"cluster.routing.allocation.enable": "primaries",
+//	})
+func (c *Client) UpdateClusterSettings(ctx context.Context, transient, persistent map[string]interface{}) error {
+	if len(transient) == 0 && len(persistent) == 0 {
+		return fmt.Errorf("no cluster settings to update")
+	}
+
+	body := map[string]interface{}{}
+	if len(transient) > 0 {
+		body["transient"] = transient
+	}
+	if len(persistent) > 0 {
+		body["persistent"] = persistent
+	}
+
+	if err := c.call(ctx, "PUT", "/_cluster/settings", body, nil); err != nil {
+		return fmt.Errorf("failed to update cluster settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package opensearchmanager
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// ClientConfig reúne as opções de construção do cliente
+type ClientConfig struct {
+	Endpoint string
+	Username string
+	Password string
+
+	// Authenticator opcional; quando nil usa Basic Auth com Username/Password
+	Authenticator Authenticator
+
+	// RetryPolicy opcional para falhas transitórias (ver DefaultRetryPolicy)
+	RetryPolicy *RetryPolicy
+
+	// DryRun faz com que operações destrutivas em lote apenas reportem os alvos
+	DryRun bool
+
+	// IncludeHidden inclui índices iniciados por "." nas operações em lote
+	IncludeHidden bool
+
+	// ExcludeClosed faz operações em lote ignorarem índices fechados
+	ExcludeClosed bool
+
+	// Logger opcional para observabilidade das requisições
+	Logger Logger
+
+	// Observer opcional para métricas das requisições
+	Observer Observer
+
+	// DeleteOptions controla o particionamento de exclusões em lote
+	DeleteOptions DeleteOptions
+
+	// CloseOptions controla fechamentos em lote
+	CloseOptions CloseOptions
+
+	// ProtectedPatterns lista índices que nunca são excluídos nem fechados
+	ProtectedPatterns []string
+
+	// WaitForActiveShards usado na criação de índices ("1", "all" ou um número)
+	WaitForActiveShards string
+
+	// UserAgent identifica o curator nos logs do cluster (padrão: DefaultUserAgent)
+	UserAgent string
+
+	// RateLimiter opcional; quando nil, RequestsPerSecond > 0 cria um
+	// limitador simples (ver NewRateLimiter)
+	RateLimiter       RateLimiter
+	RequestsPerSecond float64
+
+	// Timeout padrão por requisição, usado quando o contexto não tem prazo
+	// (padrão: 30s; negativo desativa)
+	Timeout time.Duration
+
+	// TLSConfig opcional usado como base para o transporte HTTPS
+	TLSConfig *tls.Config
+	// CACertPath aponta para um arquivo PEM com CAs adicionais (ex.: certificado autoassinado)
+	CACertPath string
+	// InsecureSkipVerify desativa a verificação do certificado do servidor
+	InsecureSkipVerify bool
+
+	// Proxy é a URL de um proxy HTTP(S) explícito (ex.: "http://proxy:3128").
+	// Vazio usa HTTP_PROXY/HTTPS_PROXY/NO_PROXY do ambiente.
+	Proxy string
+
+	// Ajustes do pool de conexões keep-alive (zero usa os padrões do pacote)
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+}
+
+// Padrões do pool de conexões. O MaxIdleConnsPerHost do Go (2) força novas
+// conexões TCP em rajadas contra um único endpoint.
+const (
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 100
+	defaultIdleConnTimeout     = 90 * time.Second
+)
+
+// NewClientWithConfig cria um cliente a partir de uma configuração completa
+func NewClientWithConfig(cfg ClientConfig) (*Client, error) {
+	tlsConfig, err := buildTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultRequestTimeout
+	} else if timeout < 0 {
+		timeout = 0
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	// O transporte padrão já usa http.ProxyFromEnvironment
+	if cfg.Proxy != "" {
+		proxyURL, err := url.Parse(cfg.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", cfg.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	transport.MaxIdleConns = orDefault(cfg.MaxIdleConns, defaultMaxIdleConns)
+	transport.MaxIdleConnsPerHost = orDefault(cfg.MaxIdleConnsPerHost, defaultMaxIdleConnsPerHost)
+	transport.IdleConnTimeout = cfg.IdleConnTimeout
+	if transport.IdleConnTimeout == 0 {
+		transport.IdleConnTimeout = defaultIdleConnTimeout
+	}
+
+	limiter := cfg.RateLimiter
+	if limiter == nil {
+		limiter = NewRateLimiter(cfg.RequestsPerSecond)
+	}
+
+	return &Client{
+		HTTPClient:          &http.Client{Transport: transport},
+		RequestTimeout:      timeout,
+		Endpoint:            cfg.Endpoint,
+		Username:            cfg.Username,
+		Password:            cfg.Password,
+		Auth:                cfg.Authenticator,
+		Retry:               cfg.RetryPolicy,
+		DryRun:              cfg.DryRun,
+		IncludeHidden:       cfg.IncludeHidden,
+		ExcludeClosed:       cfg.ExcludeClosed,
+		Logger:              cfg.Logger,
+		Observer:            cfg.Observer,
+		DeleteOptions:       cfg.DeleteOptions,
+		CloseOptions:        cfg.CloseOptions,
+		UserAgent:           cfg.UserAgent,
+		ProtectedPatterns:   cfg.ProtectedPatterns,
+		WaitForActiveShards: cfg.WaitForActiveShards,
+		RateLimiter:         limiter,
+	}, nil
+}
+
+// buildTLSConfig monta a configuração TLS a partir das opções do cliente
+func buildTLSConfig(cfg ClientConfig) (*tls.Config, error) {
+	var tlsConfig *tls.Config
+	if cfg.TLSConfig != nil {
+		tlsConfig = cfg.TLSConfig.Clone()
+	} else {
+		tlsConfig = &tls.Config{}
+	}
+
+	if cfg.InsecureSkipVerify {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if cfg.CACertPath != "" {
+		pem, err := os.ReadFile(cfg.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+
+		pool := tlsConfig.RootCAs
+		if pool == nil {
+			if pool, err = x509.SystemCertPool(); err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates found in %s", cfg.CACertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// orDefault retorna value ou def quando value não foi informado
+func orDefault(value, def int) int {
+	if value > 0 {
+		return value
+	}
+	return def
+}
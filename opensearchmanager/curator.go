@@ -2,12 +2,16 @@ package opensearchmanager
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -19,31 +23,386 @@ type Client struct {
 	Endpoint   string
 	Username   string
 	Password   string
+
+	// Auth substitui o Basic Auth padrão (ex.: AWSV4Authenticator)
+	Auth Authenticator
+
+	// Retry define novas tentativas em falhas transitórias; nil desativa
+	Retry *RetryPolicy
+
+	// DryRun faz com que exclusões e fechamentos em lote apenas calculem
+	// os índices afetados, sem enviar a operação ao cluster
+	DryRun bool
+
+	// IncludeHidden permite que operações em lote atinjam índices de
+	// sistema/ocultos (ex.: .kibana), ignorados por padrão
+	IncludeHidden bool
+
+	// ExcludeClosed faz com que operações em lote ignorem índices fechados
+	ExcludeClosed bool
+
+	// RequestTimeout limita cada requisição cujo contexto não tenha prazo.
+	// Para operações longas (shrink, force-merge) passe um contexto com o
+	// prazo desejado, que tem precedência; zero desativa o limite.
+	RequestTimeout time.Duration
+
+	// Logger recebe método, caminho, status e duração de cada tentativa
+	Logger Logger
+
+	// Observer recebe métricas de cada tentativa (ver pacote metrics)
+	Observer Observer
+
+	// DeleteOptions controla o particionamento das exclusões em lote feitas
+	// por DeleteIndices e pelas rotinas de Cleanup
+	DeleteOptions DeleteOptions
+
+	// CloseOptions controla os fechamentos feitos por CloseIndices
+	CloseOptions CloseOptions
+
+	// UserAgent enviado em todas as requisições (vazio usa DefaultUserAgent)
+	UserAgent string
+
+	// ProtectedPatterns lista padrões glob de índices que nunca são excluídos
+	// nem fechados (ver DefaultProtectedPatterns)
+	ProtectedPatterns []string
+
+	// WaitForActiveShards é enviado em CreateIndex, CloneIndex, SplitIndex e
+	// ShrinkIndex para que a chamada só retorne com o índice utilizável
+	// ("1", "all" ou um número); vazio usa o padrão do cluster
+	WaitForActiveShards string
+
+	// RateLimiter espaça as requisições para suavizar a carga no cluster;
+	// nil não aplica limite
+	RateLimiter RateLimiter
 }
 
-// NewClient cria uma nova instância do cliente
+// defaultRequestTimeout é o prazo padrão por requisição
+const defaultRequestTimeout = 30 * time.Second
+
+// NewClient cria uma nova instância do cliente com a configuração padrão
 func NewClient(endpoint, username, password string) *Client {
-	return &Client{
-		HTTPClient: &http.Client{Timeout: 30 * time.Second},
-		Endpoint:   endpoint,
-		Username:   username,
-		Password:   password,
+	// Sem CA customizada a construção não tem como falhar
+	client, _ := NewClientWithConfig(ClientConfig{
+		Endpoint: endpoint,
+		Username: username,
+		Password: password,
+	})
+	return client
+}
+
+// Clone retorna uma cópia do cliente que pode ter seus campos alterados sem
+// afetar o original. O HTTPClient (transporte, TLS e pool de conexões), o
+// Auth e o Logger são compartilhados; a RetryPolicy e os ProtectedPatterns
+// são copiados.
+func (c *Client) Clone() *Client {
+	clone := *c
+	if c.Retry != nil {
+		retry := *c.Retry
+		retry.RetryableStatusCodes = append([]int(nil), c.Retry.RetryableStatusCodes...)
+		clone.Retry = &retry
 	}
+	clone.ProtectedPatterns = append([]string(nil), c.ProtectedPatterns...)
+	return &clone
+}
+
+// WithEndpoint retorna uma cópia do cliente apontando para outro cluster
+func (c *Client) WithEndpoint(endpoint string) *Client {
+	clone := c.Clone()
+	clone.Endpoint = endpoint
+	return clone
 }
 
-// doRequest executa requisições HTTP para a API do OpenSearch
+// doRequest executa requisições HTTP para a API do OpenSearch. O prazo vem
+// do contexto; sem prazo, aplica RequestTimeout até o corpo ser fechado.
 func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, method, c.Endpoint+path, body)
+	if _, ok := ctx.Deadline(); ok || c.RequestTimeout <= 0 {
+		return c.doWithRetry(ctx, method, path, body)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.RequestTimeout)
+	resp, err := c.doWithRetry(ctx, method, path, body)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// drainAndClose consome o restante do corpo antes de fechá-lo, permitindo
+// que a conexão keep-alive seja reutilizada pelo transporte
+func drainAndClose(body io.ReadCloser) {
+	io.Copy(io.Discard, body)
+	body.Close()
+}
+
+// cancelOnClose libera o contexto da requisição quando o corpo é fechado
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// doWithRetry executa a requisição repetindo-a conforme a RetryPolicy do cliente
+func (c *Client) doWithRetry(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	// O corpo é bufferizado para poder ser reenviado em novas tentativas
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	attempts := c.Retry.attempts()
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		// Um contexto cancelado (ex.: desligamento) nunca dispara nova
+		// requisição, o que interrompe também operações de várias etapas
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Cada tentativa, inclusive retentativas, consome uma vaga do limitador
+		if err := c.waitRate(ctx); err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		resp, err := c.send(ctx, method, path, payload)
+
+		event := LogEvent{Method: method, Path: path, Duration: time.Since(start), Attempt: attempt, Err: err}
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+		}
+		c.logger().Log(ctx, event)
+		c.observer().ObserveRequest(method, path, event.StatusCode, event.Duration)
+
+		// Um 401 com autenticador renovável dispara um único refresh do token
+		// seguido de novo envio, que não conta como retentativa
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized && !refreshed {
+			if refresher, ok := c.authenticator().(TokenRefresher); ok {
+				refreshed = true
+				data, _ := io.ReadAll(resp.Body)
+				drainAndClose(resp.Body)
+				if err := refresher.RefreshToken(ctx); err != nil {
+					return nil, fmt.Errorf("failed to refresh token (%v): %w", err, newAPIError(resp.StatusCode, data))
+				}
+				attempt--
+				continue
+			}
+		}
+
+		if attempt >= attempts || !c.Retry.shouldRetry(ctx, method, resp, err) {
+			return resp, err
+		}
+
+		delay := c.Retry.delay(attempt, resp)
+		if !fitsDeadline(ctx, delay) {
+			return resp, err
+		}
+		if resp != nil {
+			drainAndClose(resp.Body)
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send executa uma única tentativa de requisição
+func (c *Client) send(ctx context.Context, method, path string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, joinURL(c.Endpoint, path), body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.SetBasicAuth(c.Username, c.Password)
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent())
+	// Pedido explicitamente: o transporte só descomprime sozinho quando ele
+	// mesmo adiciona o cabeçalho
+	req.Header.Set("Accept-Encoding", "gzip")
+	if err := c.authenticator().Sign(req); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := decompressBody(resp); err != nil {
+		drainAndClose(resp.Body)
+		return nil, err
+	}
+	return resp, nil
+}
 
-	return c.HTTPClient.Do(req)
+// decompressBody troca o corpo de uma resposta gzip pela versão descomprimida
+func decompressBody(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err == io.EOF {
+		// Respostas sem corpo (ex.: HEAD) não trazem cabeçalho gzip
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decompress response: %w", err)
+	}
+
+	resp.Body = &gzipBody{Reader: reader, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// gzipBody lê o conteúdo descomprimido e fecha o corpo original
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
+
+// userAgent retorna o User-Agent configurado ou o padrão da biblioteca
+func (c *Client) userAgent() string {
+	if c.UserAgent != "" {
+		return c.UserAgent
+	}
+	return DefaultUserAgent
+}
+
+// exists executa um HEAD no caminho: 200 indica existência, 404 ausência e
+// qualquer outro status retorna *APIError
+func (c *Client) exists(ctx context.Context, path string) (bool, error) {
+	resp, err := c.doRequest(ctx, "HEAD", path, nil)
+	if err != nil {
+		return false, err
+	}
+	defer drainAndClose(resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, newAPIError(resp.StatusCode, nil)
+	}
+}
+
+// joinURL junta o endpoint (que pode conter um prefixo de proxy, ex.:
+// "https://host/opensearch/") ao caminho da API, sem barras duplicadas
+func joinURL(endpoint, path string) string {
+	return strings.TrimRight(endpoint, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// authenticator retorna o autenticador configurado ou Basic Auth com as credenciais do cliente
+func (c *Client) authenticator() Authenticator {
+	if c.Auth != nil {
+		return c.Auth
+	}
+	return BasicAuthenticator{Username: c.Username, Password: c.Password}
+}
+
+// Do executa uma requisição arbitrária na API do OpenSearch e retorna o JSON
+// bruto da resposta. O body é serializado em JSON (nil envia sem corpo) e a
+// chamada passa pela mesma autenticação, retentativas e tratamento de erros
+// dos demais métodos.
+func (c *Client) Do(ctx context.Context, method, path string, body interface{}) (json.RawMessage, error) {
+	var raw json.RawMessage
+	if err := c.call(ctx, method, path, body, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
 }
 
+// call executa a requisição, converte status >= 400 em *APIError e decodifica
+// a resposta em out quando informado. Um body io.Reader é enviado como está.
+func (c *Client) call(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		reader = b
+	default:
+		jsonBody, err := json.Marshal(b)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reader = bytes.NewReader(jsonBody)
+	}
+
+	resp, err := c.doRequest(ctx, method, path, reader)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp.Body)
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return newAPIError(resp.StatusCode, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// ackResponse representa o corpo das operações que confirmam a alteração;
+// shards_acknowledged só existe em algumas APIs (ex.: open/close)
+type ackResponse struct {
+	Acknowledged       bool  `json:"acknowledged"`
+	ShardsAcknowledged *bool `json:"shards_acknowledged"`
+}
+
+// callAcknowledged executa a operação e retorna ErrNotAcknowledged quando o
+// cluster responde 200 sem confirmar a alteração dentro do timeout
+func (c *Client) callAcknowledged(ctx context.Context, method, path string, body interface{}) error {
+	var ack ackResponse
+	if err := c.call(ctx, method, path, body, &ack); err != nil {
+		return err
+	}
+	if !ack.Acknowledged {
+		return fmt.Errorf("%w: %s %s", ErrNotAcknowledged, method, path)
+	}
+	if ack.ShardsAcknowledged != nil && !*ack.ShardsAcknowledged {
+		return fmt.Errorf("%w for %s %s", errShardsNotAcknowledged, method, path)
+	}
+	return nil
+}
+
+// Valores de IndexInfo.Status
+const (
+	IndexStatusOpen   = "open"
+	IndexStatusClosed = "close"
+)
+
 // IndexInfo representa informações básicas de um índice
 type IndexInfo struct {
 	Name       string
@@ -51,78 +410,299 @@ type IndexInfo struct {
 	DocsCount  int64
 	StoreSize  string
 	CreateTime time.Time
+
+	// StoreSizeBytes e PrimaryStoreSizeBytes são os tamanhos convertidos para bytes
+	StoreSizeBytes        int64
+	PrimaryStoreSizeBytes int64
+
+	// Health é green, yellow ou red (vazio para índices fechados)
+	Health string
+	UUID   string
 }
 
 // ListIndices retorna todos os índices no cluster
 func (c *Client) ListIndices(ctx context.Context) ([]IndexInfo, error) {
-	resp, err := c.doRequest(ctx, "GET", "/_cat/indices?format=json", nil)
+	return c.catIndices(ctx, "")
+}
+
+// ListIndicesMatching retorna os índices que correspondem ao padrão,
+// delegando a filtragem ao próprio OpenSearch via _cat/indices/{pattern}
+func (c *Client) ListIndicesMatching(ctx context.Context, pattern string) ([]IndexInfo, error) {
+	if pattern == "" {
+		return c.ListIndices(ctx)
+	}
+	return c.catIndices(ctx, pattern)
+}
+
+// GetIndexInfo retorna as informações de um único índice. Se o índice não
+// existir o erro retornado satisfaz IsIndexNotFound.
+func (c *Client) GetIndexInfo(ctx context.Context, index string) (*IndexInfo, error) {
+	indices, err := c.catIndices(ctx, index)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var indices []struct {
-		Index      string `json:"index"`
-		Status     string `json:"status"`
-		DocsCount  string `json:"docs.count"`
-		StoreSize  string `json:"store.size"`
-		CreateTime string `json:"creation.date.string"`
+	for i := range indices {
+		if indices[i].Name == index {
+			return &indices[i], nil
+		}
 	}
+	// Um alias resolve para o nome concreto do índice
+	if len(indices) == 1 {
+		return &indices[0], nil
+	}
+	return nil, fmt.Errorf("expected a single index for %s, got %d", index, len(indices))
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&indices); err != nil {
-		return nil, err
+// catIndexRow representa uma linha retornada por _cat/indices
+type catIndexRow struct {
+	Index      string `json:"index"`
+	Status     string `json:"status"`
+	DocsCount  string `json:"docs.count"`
+	StoreSize  string `json:"store.size"`
+	PriSize    string `json:"pri.store.size"`
+	CreateDate string `json:"creation.date"`
+	CreateTime string `json:"creation.date.string"`
+	Health     string `json:"health"`
+	UUID       string `json:"uuid"`
+}
+
+// catIndicesColumns são as colunas pedidas explicitamente ao _cat/indices, para
+// que o parsing não dependa das colunas padrão de cada versão do OpenSearch
+const catIndicesColumns = "index,status,docs.count,store.size,pri.store.size,creation.date,health,uuid,creation.date.string"
+
+// toIndexInfo converte a linha do _cat em IndexInfo
+func (idx catIndexRow) toIndexInfo() IndexInfo {
+	createTime := parseCreationDate(idx.CreateDate, idx.CreateTime)
+	// Índices fechados não reportam tamanho; nesses casos o valor fica zerado
+	storeSize, _ := parseByteSize(idx.StoreSize)
+	priSize, _ := parseByteSize(idx.PriSize)
+	return IndexInfo{
+		Name:   idx.Index,
+		Status: idx.Status,
+		DocsCount: func(s string) int64 {
+			val, _ := strconv.ParseInt(s, 10, 64)
+			return val
+		}(idx.DocsCount),
+		StoreSize:             idx.StoreSize,
+		CreateTime:            createTime,
+		StoreSizeBytes:        storeSize,
+		PrimaryStoreSizeBytes: priSize,
+		Health:                idx.Health,
+		UUID:                  idx.UUID,
+	}
+}
+
+// parseCreationDate usa creation.date (epoch em ms) como fonte principal e
+// creation.date.string como alternativa; retorna zero se nenhuma for válida
+func parseCreationDate(epochMillis, dateString string) time.Time {
+	if ms, err := strconv.ParseInt(epochMillis, 10, 64); err == nil && ms > 0 {
+		return time.UnixMilli(ms).UTC()
+	}
+	if t, err := time.Parse(time.RFC3339, dateString); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// catIndices consulta _cat/indices (opcionalmente filtrado por padrão) e converte as linhas
+func (c *Client) catIndices(ctx context.Context, pattern string) ([]IndexInfo, error) {
+	path := "/_cat/indices"
+	if pattern != "" {
+		path += "/" + pattern
+	}
+	path += "?format=json&h=" + catIndicesColumns
+
+	var indices []catIndexRow
+	if err := c.call(ctx, "GET", path, nil, &indices); err != nil {
+		return nil, fmt.Errorf("failed to list indices: %w", err)
 	}
 
 	var result []IndexInfo
 	for _, idx := range indices {
-		createTime, _ := time.Parse(time.RFC3339, idx.CreateTime)
-		result = append(result, IndexInfo{
-			Name:   idx.Index,
-			Status: idx.Status,
-			DocsCount: func(s string) int64 {
-				val, _ := strconv.ParseInt(s, 10, 64)
-				return val
-			}(idx.DocsCount),
-			StoreSize:  idx.StoreSize,
-			CreateTime: createTime,
-		})
+		result = append(result, idx.toIndexInfo())
 	}
 
 	return result, nil
 }
 
-// DeleteIndices exclui índices com base em um padrão de nome
-func (c *Client) DeleteIndices(ctx context.Context, indexPattern string) error {
+// ListIndicesStream percorre os índices do cluster decodificando a resposta
+// de _cat/indices elemento a elemento, com memória limitada mesmo em
+// clusters com dezenas de milhares de índices. Um erro retornado por fn
+// interrompe a iteração e é devolvido sem alterações.
+func (c *Client) ListIndicesStream(ctx context.Context, fn func(IndexInfo) error) error {
+	resp, err := c.doRequest(ctx, "GET", "/_cat/indices?format=json&h="+catIndicesColumns, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list indices: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer drainAndClose(resp.Body)
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to list indices: %w", newAPIError(resp.StatusCode, data))
+	}
+
+	// Em uma interrupção antecipada o restante do corpo não é lido: a conexão
+	// é descartada em vez de baixar a lista inteira
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	for decoder.More() {
+		var row catIndexRow
+		if err := decoder.Decode(&row); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		if err := fn(row.toIndexInfo()); err != nil {
+			return err
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// Lista completa: consumir o restante permite reutilizar a conexão
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// DeleteIndices exclui índices com base em um padrão de nome e retorna
+// os nomes afetados (em DryRun apenas os calcula)
+func (c *Client) DeleteIndices(ctx context.Context, indexPattern string, opts ...RequestOption) ([]string, error) {
 	// Primeiro verifica se existem índices que correspondem ao padrão
+	if err := c.checkProtected(indexPattern); err != nil {
+		return nil, err
+	}
+
+	toDelete, err := c.matchIndices(ctx, indexPattern, c.unprotected(globMatcher(indexPattern)))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.deleteIndexList(ctx, toDelete, opts...); err != nil {
+		return nil, err
+	}
+
+	return toDelete, nil
+}
+
+// DeleteIndicesRegex exclui índices cujo nome inteiro corresponde à
+// expressão regular (ex.: `logs-2023\.0[1-6]`); a expressão é ancorada, então
+// "app-logs-2023.05-archive" não é atingido
+func (c *Client) DeleteIndicesRegex(ctx context.Context, pattern string, opts ...RequestOption) ([]string, error) {
+	match, err := regexMatcher(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	toDelete, err := c.matchIndices(ctx, pattern, c.unprotected(match))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.deleteIndexList(ctx, toDelete, opts...); err != nil {
+		return nil, err
+	}
+
+	return toDelete, nil
+}
+
+// ResolveIndices retorna os nomes dos índices que correspondem ao padrão glob,
+// com as mesmas regras usadas por DeleteIndices e CloseIndices (incluindo a
+// exclusão de índices ocultos). Sem correspondências retorna lista vazia.
+func (c *Client) ResolveIndices(ctx context.Context, pattern string) ([]string, error) {
+	return c.filterIndices(ctx, globMatcher(pattern))
+}
+
+// matchIndices lista os índices do cluster que satisfazem match,
+// retornando erro quando nenhum corresponde ao padrão
+func (c *Client) matchIndices(ctx context.Context, pattern string, match indexMatcher) ([]string, error) {
+	names, err := c.filterIndices(ctx, match)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no indices match pattern: %s", pattern)
+	}
+
+	return names, nil
+}
+
+// filterIndices lista os índices do cluster que satisfazem match
+func (c *Client) filterIndices(ctx context.Context, match indexMatcher) ([]string, error) {
 	indices, err := c.ListIndices(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var toDelete []string
+	names := []string{}
 	for _, idx := range indices {
-		if matched, _ := filepath.Match(indexPattern, idx.Name); matched {
-			toDelete = append(toDelete, idx.Name)
+		if !c.isExcluded(idx) && match(idx) {
+			names = append(names, idx.Name)
 		}
 	}
 
-	if len(toDelete) == 0 {
-		return fmt.Errorf("no indices match pattern: %s", indexPattern)
+	return names, nil
+}
+
+// isExcluded indica se o índice deve ser ignorado por operações em lote;
+// índices de sistema/ocultos (prefixo ".") só entram com IncludeHidden e
+// índices fechados são ignorados quando ExcludeClosed está ativo
+func (c *Client) isExcluded(idx IndexInfo) bool {
+	if !c.IncludeHidden && strings.HasPrefix(idx.Name, ".") {
+		return true
 	}
+	return c.ExcludeClosed && idx.Status == IndexStatusClosed
+}
+
+// indexMatcher decide se um índice faz parte do alvo de uma operação
+type indexMatcher func(idx IndexInfo) bool
 
-	path := fmt.Sprintf("/%s", strings.Join(toDelete, ","))
-	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+// globMatcher cria um matcher com a semântica de filepath.Match; padrões
+// separados por vírgula (como na API multi-target) são combinados com OU
+func globMatcher(pattern string) indexMatcher {
+	patterns := strings.Split(pattern, ",")
+	return func(idx IndexInfo) bool {
+		for _, p := range patterns {
+			if matched, _ := filepath.Match(p, idx.Name); matched {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// nameMatcher adapta uma função sobre o nome do índice
+func nameMatcher(match func(name string) bool) indexMatcher {
+	return func(idx IndexInfo) bool {
+		return match(idx.Name)
+	}
+}
+
+// regexMatcher compila a expressão ancorada ao nome inteiro do índice, para
+// que uma operação destrutiva não atinja nomes que apenas a contêm
+func regexMatcher(pattern string) (indexMatcher, error) {
+	re, err := regexp.Compile(`^(?:` + pattern + `)$`)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("invalid index regex %q: %w", pattern, err)
 	}
-	defer resp.Body.Close()
+	return nameMatcher(re.MatchString), nil
+}
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete indices: %s", string(body))
+// withStatus restringe o matcher a índices com o status informado
+func withStatus(match indexMatcher, status string) indexMatcher {
+	return func(idx IndexInfo) bool {
+		return idx.Status == status && match(idx)
 	}
+}
 
-	return nil
+// deleteIndexList exclui os índices informados usando as DeleteOptions do
+// cliente, sem nenhuma chamada HTTP quando o cliente está em DryRun
+func (c *Client) deleteIndexList(ctx context.Context, names []string, opts ...RequestOption) error {
+	return c.DeleteIndexNames(ctx, names, c.DeleteOptions, opts...)
 }
 
 // AliasAction representa uma ação de alias
@@ -137,20 +717,8 @@ func (c *Client) ManageAliases(ctx context.Context, actions []AliasAction) error
 		"actions": actions,
 	}
 
-	jsonBody, err := json.Marshal(body)
-	if err != nil {
-		return err
-	}
-
-	resp, err := c.doRequest(ctx, "POST", "/_aliases", bytes.NewReader(jsonBody))
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to manage aliases: %s", string(body))
+	if err := c.callAcknowledged(ctx, "POST", "/_aliases", body); err != nil {
+		return fmt.Errorf("failed to manage aliases: %w", err)
 	}
 
 	return nil
@@ -173,193 +741,656 @@ func (c *Client) ManageAliases(ctx context.Context, actions []AliasAction) error
 // }
 // client.ManageAliases(ctx, actions)
 
+// RolloverResult representa a resposta da API de rollover
+type RolloverResult struct {
+	Acknowledged       bool            `json:"acknowledged"`
+	ShardsAcknowledged bool            `json:"shards_acknowledged"`
+	OldIndex           string          `json:"old_index"`
+	NewIndex           string          `json:"new_index"`
+	RolledOver         bool            `json:"rolled_over"`
+	DryRun             bool            `json:"dry_run"`
+	Conditions         map[string]bool `json:"conditions"`
+}
+
 // Rollover executa uma operação de rollover em um alias
 func (c *Client) Rollover(ctx context.Context, alias string, conditions map[string]interface{}) error {
+	_, err := c.RolloverWithResult(ctx, alias, conditions)
+	return err
+}
+
+// RolloverWithResult executa o rollover e retorna a resposta decodificada,
+// indicando se o rollover ocorreu e quais condições foram atendidas
+func (c *Client) RolloverWithResult(ctx context.Context, alias string, conditions map[string]interface{}) (*RolloverResult, error) {
+	return c.RolloverWithOptions(ctx, alias, conditions, RolloverOptions{})
+}
+
+// RolloverOptions define parâmetros opcionais do rollover
+type RolloverOptions struct {
+	// NewIndex define o nome do novo índice em vez do incremento automático
+	NewIndex string
+	// DryRun apenas avalia as condições, sem executar o rollover
+	DryRun bool
+}
+
+// RolloverWithOptions executa o rollover com nome de destino e/ou dry_run.
+// alias também pode ser o nome de um data stream (sem NewIndex).
+// Em dry_run, Conditions indica quais condições já seriam atendidas.
+func (c *Client) RolloverWithOptions(ctx context.Context, alias string, conditions map[string]interface{}, opts RolloverOptions) (*RolloverResult, error) {
 	body := map[string]interface{}{
 		"conditions": conditions,
 	}
 
-	jsonBody, err := json.Marshal(body)
-	if err != nil {
-		return err
+	path := fmt.Sprintf("/%s/_rollover", alias)
+	if opts.NewIndex != "" {
+		path += "/" + opts.NewIndex
+	}
+	if opts.DryRun {
+		path += "?dry_run=true"
 	}
 
-	path := fmt.Sprintf("/%s/_rollover", alias)
-	resp, err := c.doRequest(ctx, "POST", path, bytes.NewReader(jsonBody))
-	if err != nil {
-		return err
+	var result RolloverResult
+	if err := c.call(ctx, "POST", path, body, &result); err != nil {
+		return nil, fmt.Errorf("failed to rollover index: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to rollover index: %s", string(body))
+	return &result, nil
+}
+
+// RolloverConditions define as condições de rollover com campos tipados;
+// campos vazios são omitidos
+type RolloverConditions struct {
+	MaxAge              string `json:"max_age,omitempty" yaml:"max_age,omitempty"`
+	MaxDocs             int64  `json:"max_docs,omitempty" yaml:"max_docs,omitempty"`
+	MaxSize             string `json:"max_size,omitempty" yaml:"max_size,omitempty"`
+	MaxPrimaryShardSize string `json:"max_primary_shard_size,omitempty" yaml:"max_primary_shard_size,omitempty"`
+}
+
+// Map converte as condições no formato livre aceito por Rollover, permitindo
+// acrescentar condições ainda não tipadas
+func (rc RolloverConditions) Map() map[string]interface{} {
+	conditions := map[string]interface{}{}
+	if rc.MaxAge != "" {
+		conditions["max_age"] = rc.MaxAge
 	}
+	if rc.MaxDocs > 0 {
+		conditions["max_docs"] = rc.MaxDocs
+	}
+	if rc.MaxSize != "" {
+		conditions["max_size"] = rc.MaxSize
+	}
+	if rc.MaxPrimaryShardSize != "" {
+		conditions["max_primary_shard_size"] = rc.MaxPrimaryShardSize
+	}
+	return conditions
+}
 
-	return nil
+// RolloverWithConditions executa o rollover com condições tipadas
+func (c *Client) RolloverWithConditions(ctx context.Context, alias string, conditions RolloverConditions, opts RolloverOptions) (*RolloverResult, error) {
+	return c.RolloverWithOptions(ctx, alias, conditions.Map(), opts)
 }
 
 // Reindex executa uma operação de reindexação
 func (c *Client) Reindex(ctx context.Context, source, dest string, query map[string]interface{}) error {
+	return c.ReindexWithOptions(ctx, source, dest, ReindexOptions{Query: query})
+}
+
+// ReindexAsync dispara a reindexação em segundo plano (wait_for_completion=false)
+// e retorna o ID da task para acompanhamento com GetTask
+func (c *Client) ReindexAsync(ctx context.Context, source, dest string, query map[string]interface{}) (string, error) {
+	return c.ReindexAsyncWithOptions(ctx, source, dest, ReindexOptions{Query: query})
+}
+
+// SlicesAuto deixa o cluster escolher o número de slices da reindexação
+const SlicesAuto = -1
+
+// ReindexOptions reúne os parâmetros opcionais da API _reindex
+type ReindexOptions struct {
+	// Query filtra os documentos copiados; nil copia todos
+	Query map[string]interface{}
+	// Script transforma os documentos durante a cópia
+	// (ex.: {"source": "ctx._source.remove('tmp')", "lang": "painless"})
+	Script map[string]interface{}
+	// Slices paraleliza a reindexação; SlicesAuto usa o valor do cluster
+	// e zero mantém uma única slice
+	Slices int
+	// RequestsPerSecond limita a vazão; zero não aplica limite
+	RequestsPerSecond float64
+	// DisableRefresh desativa o refresh_interval do destino durante a
+	// reindexação síncrona, restaurando-o e executando um Refresh ao final.
+	// Só tem efeito se o destino já existir.
+	DisableRefresh bool
+}
+
+// ReindexWithOptions executa a reindexação com slicing, throttling e script
+func (c *Client) ReindexWithOptions(ctx context.Context, source, dest string, opts ReindexOptions) error {
+	if !opts.DisableRefresh {
+		if err := c.call(ctx, "POST", reindexPath(opts, false), reindexBody(source, dest, opts), nil); err != nil {
+			return fmt.Errorf("failed to reindex: %w", err)
+		}
+		return nil
+	}
+
+	settings, err := c.GetIndexSettings(ctx, dest)
+	if IsIndexNotFound(err) {
+		opts.DisableRefresh = false
+		return c.ReindexWithOptions(ctx, source, dest, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read dest refresh interval: %w", err)
+	}
+
+	// 1. Desativar o refresh no destino
+	refreshOff := map[string]interface{}{"index.refresh_interval": "-1"}
+	if err := c.UpdateIndexSettings(ctx, dest, refreshOff); err != nil {
+		return fmt.Errorf("failed to disable refresh on dest index: %w", err)
+	}
+
+	// 2. Reindexar
+	var reindexErr error
+	if err := c.call(ctx, "POST", reindexPath(opts, false), reindexBody(source, dest, opts), nil); err != nil {
+		reindexErr = fmt.Errorf("failed to reindex: %w", err)
+	}
+
+	// 3. Restaurar o intervalo original (ausente volta ao padrão), mesmo se a
+	// reindexação falhou
+	restore := map[string]interface{}{"index.refresh_interval": settings["index.refresh_interval"]}
+	if err := c.UpdateIndexSettings(ctx, dest, restore); err != nil {
+		if reindexErr != nil {
+			return fmt.Errorf("%w (also failed to restore refresh interval: %v)", reindexErr, err)
+		}
+		return fmt.Errorf("failed to restore refresh interval: %w", err)
+	}
+	if reindexErr != nil {
+		return reindexErr
+	}
+
+	// 4. Tornar os documentos copiados visíveis; dest vai pelo nome, como nas
+	// etapas anteriores, pois pode ser um alias ou um índice oculto
+	if err := c.call(ctx, "POST", fmt.Sprintf("/%s/_refresh", dest), nil, nil); err != nil {
+		return fmt.Errorf("failed to refresh dest index: %w", err)
+	}
+	return nil
+}
+
+// ReindexAsyncWithOptions é a versão em segundo plano de ReindexWithOptions;
+// DisableRefresh é ignorado, pois não há como restaurar o intervalo ao final
+func (c *Client) ReindexAsyncWithOptions(ctx context.Context, source, dest string, opts ReindexOptions) (string, error) {
+	var result struct {
+		Task string `json:"task"`
+	}
+
+	if err := c.call(ctx, "POST", reindexPath(opts, true), reindexBody(source, dest, opts), &result); err != nil {
+		return "", fmt.Errorf("failed to start reindex: %w", err)
+	}
+	if result.Task == "" {
+		return "", fmt.Errorf("reindex response did not include a task id")
+	}
+
+	return result.Task, nil
+}
+
+// reindexPath monta o caminho da API _reindex com os parâmetros de query
+func reindexPath(opts ReindexOptions, async bool) string {
+	params := url.Values{}
+	if async {
+		params.Set("wait_for_completion", "false")
+	}
+	if opts.Slices == SlicesAuto {
+		params.Set("slices", "auto")
+	} else if opts.Slices > 0 {
+		params.Set("slices", strconv.Itoa(opts.Slices))
+	}
+	if opts.RequestsPerSecond > 0 {
+		params.Set("requests_per_second", strconv.FormatFloat(opts.RequestsPerSecond, 'f', -1, 64))
+	}
+
+	if len(params) == 0 {
+		return "/_reindex"
+	}
+	return "/_reindex?" + params.Encode()
+}
+
+// reindexBody monta o corpo da API _reindex; query nil copia todos os documentos
+func reindexBody(source, dest string, opts ReindexOptions) map[string]interface{} {
+	src := map[string]interface{}{
+		"index": source,
+	}
+	if opts.Query != nil {
+		src["query"] = opts.Query
+	}
+
 	body := map[string]interface{}{
-		"source": map[string]interface{}{
-			"index": source,
-			"query": query,
-		},
+		"source": src,
 		"dest": map[string]interface{}{
 			"index": dest,
 		},
 	}
+	if opts.Script != nil {
+		body["script"] = opts.Script
+	}
 
-	jsonBody, err := json.Marshal(body)
+	return body
+}
+
+// CloseIndices fecha índices que correspondem a um padrão e retorna
+// os nomes afetados (em DryRun apenas os calcula)
+func (c *Client) CloseIndices(ctx context.Context, indexPattern string, opts ...RequestOption) ([]string, error) {
+	if err := c.checkProtected(indexPattern); err != nil {
+		return nil, err
+	}
+
+	toClose, err := c.matchIndices(ctx, indexPattern, c.unprotected(globMatcher(indexPattern)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	resp, err := c.doRequest(ctx, "POST", "/_reindex", bytes.NewReader(jsonBody))
+	if c.DryRun {
+		return toClose, nil
+	}
+
+	if err := c.closeIndexList(ctx, toClose, opts...); err != nil {
+		return nil, err
+	}
+
+	return toClose, nil
+}
+
+// CloseIndicesRegex fecha índices cujo nome inteiro corresponde à expressão
+// regular, com a mesma ancoragem de DeleteIndicesRegex
+func (c *Client) CloseIndicesRegex(ctx context.Context, pattern string, opts ...RequestOption) ([]string, error) {
+	match, err := regexMatcher(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	toClose, err := c.matchIndices(ctx, pattern, c.unprotected(match))
 	if err != nil {
+		return nil, err
+	}
+
+	if c.DryRun {
+		return toClose, nil
+	}
+
+	if err := c.closeIndexList(ctx, toClose, opts...); err != nil {
+		return nil, err
+	}
+
+	return toClose, nil
+}
+
+// closeIndexList fecha os índices informados em uma única requisição
+func (c *Client) closeIndexList(ctx context.Context, names []string, opts ...RequestOption) error {
+	if err := c.checkProtectedNames(names); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to reindex: %s", string(body))
+	if c.CloseOptions.ContinueOnError {
+		return c.closeEachIndex(ctx, names, opts)
+	}
+
+	path := withRequestOptions(fmt.Sprintf("/%s/_close", strings.Join(names, ",")), opts)
+	if err := c.callAcknowledged(ctx, "POST", path, nil); err != nil {
+		return fmt.Errorf("failed to close indices: %w", err)
+	}
+
+	return nil
+}
+
+// closeEachIndex fecha um índice por requisição, reunindo as falhas em um
+// *BatchError sem interromper os demais
+func (c *Client) closeEachIndex(ctx context.Context, names []string, opts []RequestOption) error {
+	batchErr := &BatchError{}
+	for _, name := range names {
+		path := withRequestOptions(fmt.Sprintf("/%s/_close", name), opts)
+		if err := c.callAcknowledged(ctx, "POST", path, nil); err != nil {
+			batchErr.Failures = append(batchErr.Failures, BatchFailure{Indices: []string{name}, Err: err})
+			continue
+		}
+		batchErr.Succeeded = append(batchErr.Succeeded, name)
 	}
 
+	if len(batchErr.Failures) > 0 {
+		return fmt.Errorf("failed to close indices: %w", batchErr)
+	}
 	return nil
 }
 
-// CloseIndices fecha índices que correspondem a um padrão
-func (c *Client) CloseIndices(ctx context.Context, indexPattern string) error {
+// funcionalidades adicionais
+// CleanupByAge remove índices mais antigos que N dias e retorna os nomes
+// removidos para auditoria; sem correspondências retorna uma lista vazia.
+// Índices sem data de criação conhecida nunca são removidos.
+func (c *Client) CleanupByAge(ctx context.Context, indexPrefix string, days int) ([]string, error) {
+	cutoff := time.Now().AddDate(0, 0, -days)
 	indices, err := c.ListIndices(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var toClose []string
+	toDelete := []string{}
 	for _, idx := range indices {
-		if matched, _ := filepath.Match(indexPattern, idx.Name); matched {
-			toClose = append(toClose, idx.Name)
+		if idx.CreateTime.IsZero() {
+			continue
+		}
+		if !c.skipDestructive(idx) && strings.HasPrefix(idx.Name, indexPrefix) && idx.CreateTime.Before(cutoff) {
+			toDelete = append(toDelete, idx.Name)
 		}
 	}
 
-	if len(toClose) == 0 {
-		return fmt.Errorf("no indices match pattern: %s", indexPattern)
+	if len(toDelete) == 0 {
+		return toDelete, nil
+	}
+
+	if err := c.deleteIndexList(ctx, toDelete); err != nil {
+		return nil, err
 	}
 
-	path := fmt.Sprintf("/%s/_close", strings.Join(toClose, ","))
-	resp, err := c.doRequest(ctx, "POST", path, nil)
+	return toDelete, nil
+}
+
+// IndexAges retorna a idade (desde a criação) de cada índice que corresponde
+// ao padrão, para alertar sobre índices além da retenção antes da limpeza.
+// Índices sem data de criação conhecida são devolvidos à parte em unknown.
+func (c *Client) IndexAges(ctx context.Context, pattern string) (ages map[string]time.Duration, unknown []string, err error) {
+	indices, err := c.ListIndices(ctx)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to close indices: %s", string(body))
+	match := globMatcher(pattern)
+	now := time.Now()
+	ages = make(map[string]time.Duration)
+	unknown = []string{}
+	for _, idx := range indices {
+		if c.isExcluded(idx) || !match(idx) {
+			continue
+		}
+		if idx.CreateTime.IsZero() {
+			unknown = append(unknown, idx.Name)
+			continue
+		}
+		ages[idx.Name] = now.Sub(idx.CreateTime)
 	}
+	sort.Strings(unknown)
 
-	return nil
+	return ages, unknown, nil
 }
 
-// funcionalidades adicionais
-// CleanupByAge remove índices mais antigos que N dias
-func (c *Client) CleanupByAge(ctx context.Context, indexPrefix string, days int) error {
-	cutoff := time.Now().AddDate(0, 0, -days)
+// CleanupBySize remove os índices mais antigos até que o tamanho total
+// dos índices com o prefixo fique abaixo de maxTotalBytes. Índices sem data
+// de criação conhecida não entram na conta nem são removidos.
+func (c *Client) CleanupBySize(ctx context.Context, indexPrefix string, maxTotalBytes int64) ([]string, error) {
 	indices, err := c.ListIndices(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var toDelete []string
+	var matching []IndexInfo
+	var total int64
 	for _, idx := range indices {
-		if strings.HasPrefix(idx.Name, indexPrefix) && idx.CreateTime.Before(cutoff) {
-			toDelete = append(toDelete, idx.Name)
+		// Sem data de criação não há como ordenar por idade
+		if idx.CreateTime.IsZero() {
+			continue
+		}
+		if !c.skipDestructive(idx) && strings.HasPrefix(idx.Name, indexPrefix) {
+			matching = append(matching, idx)
+			total += idx.StoreSizeBytes
+		}
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].CreateTime.Before(matching[j].CreateTime)
+	})
+
+	var toDelete []string
+	for _, idx := range matching {
+		if total < maxTotalBytes {
+			break
 		}
+		toDelete = append(toDelete, idx.Name)
+		total -= idx.StoreSizeBytes
 	}
 
 	if len(toDelete) == 0 {
-		return nil
+		return nil, nil
+	}
+
+	if err := c.deleteIndexList(ctx, toDelete); err != nil {
+		return nil, err
+	}
+
+	return toDelete, nil
+}
+
+// CleanupByCount mantém apenas os `keep` índices mais recentes do prefixo
+// e remove os demais. Índices sem data de criação conhecida são ignorados.
+func (c *Client) CleanupByCount(ctx context.Context, indexPrefix string, keep int) ([]string, error) {
+	if keep < 0 {
+		return nil, fmt.Errorf("keep must not be negative: %d", keep)
 	}
 
-	path := fmt.Sprintf("/%s", strings.Join(toDelete, ","))
-	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	indices, err := c.ListIndices(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete old indices: %s", string(body))
+	var matching []IndexInfo
+	for _, idx := range indices {
+		if idx.CreateTime.IsZero() {
+			continue
+		}
+		if !c.skipDestructive(idx) && strings.HasPrefix(idx.Name, indexPrefix) {
+			matching = append(matching, idx)
+		}
 	}
 
-	return nil
+	if len(matching) <= keep {
+		return nil, nil
+	}
+
+	// Mais recentes primeiro
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].CreateTime.After(matching[j].CreateTime)
+	})
+
+	var toDelete []string
+	for _, idx := range matching[keep:] {
+		toDelete = append(toDelete, idx.Name)
+	}
+
+	if err := c.deleteIndexList(ctx, toDelete); err != nil {
+		return nil, err
+	}
+
+	return toDelete, nil
+}
+
+// CleanupByNameDate remove índices cuja data no nome (sufixo após o prefixo,
+// interpretado com o layout Go informado, ex.: "2006.01.02") é mais antiga
+// que N dias. Índices cujo sufixo não corresponde ao layout são ignorados.
+func (c *Client) CleanupByNameDate(ctx context.Context, prefix, dateLayout string, days int) ([]string, error) {
+	cutoff := time.Now().AddDate(0, 0, -days)
+	indices, err := c.ListIndices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	toDelete := []string{}
+	for _, idx := range indices {
+		if c.skipDestructive(idx) || !strings.HasPrefix(idx.Name, prefix) {
+			continue
+		}
+		date, err := time.Parse(dateLayout, strings.TrimPrefix(idx.Name, prefix))
+		if err != nil {
+			continue
+		}
+		if date.Before(cutoff) {
+			toDelete = append(toDelete, idx.Name)
+		}
+	}
+
+	if len(toDelete) == 0 {
+		return toDelete, nil
+	}
+
+	if err := c.deleteIndexList(ctx, toDelete); err != nil {
+		return nil, err
+	}
+
+	return toDelete, nil
 }
 
 // OpenIndex abre um índice fechado
 func (c *Client) OpenIndex(ctx context.Context, indexName string) error {
 	path := fmt.Sprintf("/%s/_open", indexName)
-	resp, err := c.doRequest(ctx, "POST", path, nil)
+	if err := c.callAcknowledged(ctx, "POST", path, nil); err != nil {
+		return fmt.Errorf("failed to open index: %w", err)
+	}
+	return nil
+}
+
+// OpenIndices abre apenas os índices atualmente fechados que correspondem
+// ao padrão e retorna os nomes afetados. ExcludeClosed não se aplica aqui,
+// já que os fechados são justamente o alvo; índices ocultos seguem excluídos.
+func (c *Client) OpenIndices(ctx context.Context, indexPattern string) ([]string, error) {
+	withClosed := c.Clone()
+	withClosed.ExcludeClosed = false
+
+	toOpen, err := withClosed.matchIndices(ctx, indexPattern, withStatus(globMatcher(indexPattern), IndexStatusClosed))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to open index: %s", string(body))
+	if err := c.OpenIndex(ctx, strings.Join(toOpen, ",")); err != nil {
+		return nil, err
 	}
-	return nil
+
+	return toOpen, nil
 }
 
-// ShrinkIndex corrigido - agora com suporte completo
+// ShrinkIndex reduz o número de shards de source criando target. Se alguma
+// etapa falhar, o target parcial é removido e o source volta a ficar aberto
+// com o bloqueio de escrita que tinha antes da operação.
+//
+// Cancelar ctx interrompe as etapas seguintes, mas não o rollback: a exclusão
+// do target e a reabertura e restauração do source ainda são enviadas depois
+// do cancelamento, para não deixar o source fechado ou bloqueado.
 func (c *Client) ShrinkIndex(ctx context.Context, source, target string, settings map[string]interface{}) error {
-	// 1. Fechar o índice fonte
-	if err := c.CloseIndices(ctx, source); err != nil {
-		return fmt.Errorf("failed to close source index: %w", err)
+	return c.ShrinkIndexWithOptions(ctx, source, target, settings, ShrinkOptions{})
+}
+
+// ShrinkOptions define parâmetros opcionais do shrink
+type ShrinkOptions struct {
+	// KeepSourceOpen mantém o source aberto, apenas com o bloqueio de
+	// escrita, evitando a indisponibilidade nas versões que suportam esse
+	// modo. O padrão (false) fecha e reabre o source, como faz ShrinkIndex.
+	KeepSourceOpen bool
+}
+
+// ShrinkIndexWithOptions é ShrinkIndex com controle sobre o fechamento do
+// source. Em DryRun apenas valida o source e o target, sem alterar o cluster.
+func (c *Client) ShrinkIndexWithOptions(ctx context.Context, source, target string, settings map[string]interface{}, opts ShrinkOptions) error {
+	// 1. Registrar o estado original do source para o rollback e para o
+	// número de réplicas do target
+	original, err := c.GetIndexSettings(ctx, source)
+	if err != nil {
+		return fmt.Errorf("failed to read source settings: %w", err)
+	}
+
+	// O número de shards do target precisa dividir o do source; validar antes
+	// de qualquer alteração evita deixar o source fechado
+	sourceShards, err := strconv.Atoi(fmt.Sprint(original["index.number_of_shards"]))
+	if err != nil {
+		return fmt.Errorf("invalid number_of_shards for index %s: %q", source, fmt.Sprint(original["index.number_of_shards"]))
+	}
+	targetShards, err := strconv.Atoi(fmt.Sprint(settings["number_of_shards"]))
+	if err != nil || targetShards < 1 {
+		return fmt.Errorf("invalid target number_of_shards: %v", settings["number_of_shards"])
+	}
+	if targetShards >= sourceShards || sourceShards%targetShards != 0 {
+		return fmt.Errorf("cannot shrink %d shards to %d: target must be a factor of the source shard count", sourceShards, targetShards)
+	}
+
+	// Um target pré-existente não pertence a esta operação e jamais pode ser
+	// removido pelo rollback; recusar antes de tocar no source
+	exists, err := c.IndexExists(ctx, target)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("cannot shrink %s: target index %s already exists", source, target)
 	}
 
-	// 2. Configurar o shrink
+	// Em DryRun apenas as validações acima são feitas
+	if c.DryRun {
+		return nil
+	}
+
+	// 2. Fechar o índice fonte ou apenas bloquear sua escrita. Uma falha
+	// aqui pode ter sido aplicada mesmo assim (ex.: acknowledged:false), por
+	// isso também passa pelo rollback, que ainda não tem target a remover.
+	// shards_acknowledged:false não é fatal em open/close: a prontidão é
+	// verificada por WaitForGreen mais adiante.
+	if !opts.KeepSourceOpen {
+		if err := c.closeIndexList(ctx, []string{source}); err != nil && !shardsPending(err) {
+			return c.rollbackShrink(ctx, source, target, original, false, fmt.Errorf("failed to close source index: %w", err))
+		}
+	} else if err := c.SetIndexBlock(ctx, source, "write", true); err != nil {
+		return c.rollbackShrink(ctx, source, target, original, false, fmt.Errorf("failed to block writes on source index: %w", err))
+	}
+
+	// 3. Configurar o shrink
 	body := map[string]interface{}{
 		"settings": mergeSettings(settings, map[string]interface{}{
 			"index.blocks.write":       true,
 			"index.number_of_replicas": 0,
-			"index.number_of_shards":   settings["number_of_shards"],
+			"index.number_of_shards":   targetShards,
 		}),
 	}
 
-	jsonBody, err := json.Marshal(body)
-	if err != nil {
-		return fmt.Errorf("failed to marshal shrink settings: %w", err)
+	// 4. Executar o shrink
+	path := c.withActiveShards(fmt.Sprintf("/%s/_shrink/%s", source, target))
+	if err := c.call(ctx, "POST", path, body, nil); err != nil {
+		// Se o cluster recusou o shrink porque o target já existe, ele não
+		// foi criado por esta operação
+		return c.rollbackShrink(ctx, source, target, original, !IsIndexAlreadyExists(err), fmt.Errorf("shrink failed: %w", err))
 	}
 
-	// 3. Executar o shrink
-	path := fmt.Sprintf("/%s/_shrink/%s", source, target)
-	resp, err := c.doRequest(ctx, "POST", path, bytes.NewReader(jsonBody))
-	if err != nil {
-		return fmt.Errorf("failed to execute shrink request: %w", err)
-	}
-	defer resp.Body.Close()
+	// 5. Reabrir os índices ou devolver ao source o bloqueio original
+	if !opts.KeepSourceOpen {
+		if err := c.OpenIndex(ctx, source); err != nil && !shardsPending(err) {
+			return c.rollbackShrink(ctx, source, target, original, true, fmt.Errorf("failed to reopen source index: %w", err))
+		}
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("shrink failed with status %d: %s", resp.StatusCode, string(body))
+		if err := c.OpenIndex(ctx, target); err != nil && !shardsPending(err) {
+			return c.rollbackShrink(ctx, source, target, original, true, fmt.Errorf("failed to open target index: %w", err))
+		}
+	} else {
+		restore := map[string]interface{}{"index.blocks.write": original["index.blocks.write"]}
+		if err := c.UpdateIndexSettings(ctx, source, restore); err != nil {
+			return c.rollbackShrink(ctx, source, target, original, true, fmt.Errorf("failed to restore source write block: %w", err))
+		}
 	}
 
-	// 4. Reabrir os índices
-	if err := c.OpenIndex(ctx, source); err != nil {
-		return fmt.Errorf("failed to reopen source index: %w", err)
+	// 6. Verificar que o novo índice existe e está green antes de alterá-lo
+	if exists, err := c.IndexExists(ctx, target); err != nil || !exists {
+		if err == nil {
+			err = fmt.Errorf("index %s does not exist", target)
+		}
+		return c.rollbackShrink(ctx, source, target, original, true, fmt.Errorf("shrunk index not found: %w", err))
 	}
-
-	if err := c.OpenIndex(ctx, target); err != nil {
-		return fmt.Errorf("failed to open target index: %w", err)
+	if err := c.WaitForGreen(ctx, target, readyTimeout); err != nil {
+		return c.rollbackShrink(ctx, source, target, original, true, fmt.Errorf("shrunk index not ready: %w", err))
 	}
 
-	// 5. Aplicar configurações finais no novo índice
+	// 7. Aplicar configurações finais no novo índice; sem número de réplicas
+	// informado, o target herda o do source
+	replicas, ok := settings["number_of_replicas"]
+	if !ok {
+		replicas = original["index.number_of_replicas"]
+	}
 	finalSettings := map[string]interface{}{
-		"index.number_of_replicas": settings["number_of_replicas"],
+		"index.number_of_replicas": replicas,
 		"index.blocks.write":       nil, // Remove o bloqueio
 	}
 
@@ -370,6 +1401,53 @@ func (c *Client) ShrinkIndex(ctx context.Context, source, target string, setting
 	return nil
 }
 
+// rollbackShrink desfaz um shrink que falhou: remove o target parcial, se
+// esta operação pode tê-lo criado, e devolve ao source o estado aberto e o
+// bloqueio de escrita originais. Falhas no rollback são anexadas ao erro
+// original. O rollback roda mesmo com o contexto cancelado, para não deixar
+// o cluster em estado parcial.
+func (c *Client) rollbackShrink(ctx context.Context, source, target string, original map[string]interface{}, deleteTarget bool, cause error) error {
+	ctx = context.WithoutCancel(ctx)
+	var failures []string
+
+	// A exclusão passa por DeleteIndexNames para respeitar ProtectedPatterns
+	if deleteTarget {
+		if err := c.DeleteIndexNames(ctx, []string{target}, DeleteOptions{}); err != nil && !IsIndexNotFound(err) {
+			failures = append(failures, fmt.Sprintf("delete target: %v", err))
+		}
+	}
+
+	if err := c.OpenIndex(ctx, source); err != nil && !shardsPending(err) {
+		failures = append(failures, fmt.Sprintf("reopen source: %v", err))
+	}
+
+	// Um valor ausente remove o bloqueio, restaurando o padrão
+	restore := map[string]interface{}{
+		"index.blocks.write":       original["index.blocks.write"],
+		"index.number_of_replicas": original["index.number_of_replicas"],
+	}
+	if err := c.UpdateIndexSettings(ctx, source, restore); err != nil {
+		failures = append(failures, fmt.Sprintf("restore source settings: %v", err))
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%w (rollback incomplete: %s)", cause, strings.Join(failures, "; "))
+	}
+	return cause
+}
+
+// withActiveShards acrescenta wait_for_active_shards ao caminho, se configurado
+func (c *Client) withActiveShards(path string) string {
+	if c.WaitForActiveShards == "" {
+		return path
+	}
+	return path + "?wait_for_active_shards=" + url.QueryEscape(c.WaitForActiveShards)
+}
+
+// readyTimeout é a espera máxima pelo status green após shrink/clone;
+// fica abaixo do RequestTimeout padrão de 30s
+const readyTimeout = 20 * time.Second
+
 // mergeSettings combina configurações de índices
 func mergeSettings(base, override map[string]interface{}) map[string]interface{} {
 	result := make(map[string]interface{})
@@ -385,21 +1463,42 @@ func mergeSettings(base, override map[string]interface{}) map[string]interface{}
 // UpdateIndexSettings atualiza as configurações de um índice
 func (c *Client) UpdateIndexSettings(ctx context.Context, indexName string, settings map[string]interface{}) error {
 	body := map[string]interface{}{"settings": settings}
-	jsonBody, err := json.Marshal(body)
-	if err != nil {
-		return err
-	}
 
 	path := fmt.Sprintf("/%s/_settings", indexName)
-	resp, err := c.doRequest(ctx, "PUT", path, bytes.NewReader(jsonBody))
+	if err := c.callAcknowledged(ctx, "PUT", path, body); err != nil {
+		return fmt.Errorf("failed to update settings: %w", err)
+	}
+	return nil
+}
+
+// UpdateIndexSettingsMatching aplica as configurações a todos os índices que
+// correspondem ao padrão com uma única requisição _settings
+func (c *Client) UpdateIndexSettingsMatching(ctx context.Context, pattern string, settings map[string]interface{}) error {
+	indices, err := c.matchIndices(ctx, pattern, globMatcher(pattern))
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update settings: %s", string(body))
+	return c.UpdateIndexSettings(ctx, strings.Join(indices, ","), settings)
+}
+
+// SetIndexBlock ativa ou remove um bloqueio de índice (write, read, metadata,
+// read_only ou read_only_allow_delete). Útil para limpar o read_only_allow_delete
+// aplicado automaticamente sob pressão de disco.
+func (c *Client) SetIndexBlock(ctx context.Context, index, block string, enabled bool) error {
+	switch block {
+	case "write", "read", "metadata", "read_only", "read_only_allow_delete":
+	default:
+		return fmt.Errorf("unknown index block: %s", block)
 	}
-	return nil
+
+	// Remover a configuração (nil) restaura o padrão em vez de fixar false
+	var value interface{}
+	if enabled {
+		value = true
+	}
+
+	return c.UpdateIndexSettings(ctx, index, map[string]interface{}{
+		"index.blocks." + block: value,
+	})
 }
@@ -0,0 +1,57 @@
+package opensearchmanager
+
+import "testing"
+
+func TestJoinURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		path     string
+		want     string
+	}{
+		{"http://host:9200", "/_cat/indices", "http://host:9200/_cat/indices"},
+		{"http://host:9200/", "/_cat/indices", "http://host:9200/_cat/indices"},
+		{"http://host:9200", "_cat/indices", "http://host:9200/_cat/indices"},
+		{"http://host:9200/", "_cat/indices", "http://host:9200/_cat/indices"},
+		{"http://host:9200/os", "/_cat/indices", "http://host:9200/os/_cat/indices"},
+		{"http://host:9200/os/", "/_cat/indices", "http://host:9200/os/_cat/indices"},
+		{"http://host:9200/os", "_cat/indices", "http://host:9200/os/_cat/indices"},
+		{"http://host:9200/os/", "/_cat/indices?format=json", "http://host:9200/os/_cat/indices?format=json"},
+	}
+
+	for _, tt := range tests {
+		if got := joinURL(tt.endpoint, tt.path); got != tt.want {
+			t.Errorf("joinURL(%q, %q) = %q, want %q", tt.endpoint, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"512", 512, false},
+		{"512b", 512, false},
+		{"1kb", 1 << 10, false},
+		{"230mb", 230 << 20, false},
+		{"1.5gb", 3 << 29, false},
+		{" 2TB ", 2 << 40, false},
+		{"1pb", 1 << 50, false},
+		{"", 0, true},
+		{"gb", 0, true},
+		{"-1kb", 0, true},
+		{"12xb", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseByteSize(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseByteSize(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseByteSize(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
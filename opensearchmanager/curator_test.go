@@ -0,0 +1,467 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager"
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+// catRow monta uma linha de _cat/indices criada há days dias; days < 0 omite
+// a data de criação
+func catRow(name, status, size string, days int) string {
+	created := ""
+	if days >= 0 {
+		created = fmt.Sprint(time.Now().AddDate(0, 0, -days).UnixMilli())
+	}
+	return fmt.Sprintf(`{"index":%q,"status":%q,"store.size":%q,"pri.store.size":%q,"creation.date":%q}`,
+		name, status, size, size, created)
+}
+
+func catBody(rows ...string) string {
+	return "[" + strings.Join(rows, ",") + "]"
+}
+
+// writes retorna as requisições que alteram o cluster, como "MÉTODO caminho"
+func writes(srv *opensearchtest.Server) []string {
+	var out []string
+	for _, req := range srv.Requests() {
+		if req.Method != "GET" && req.Method != "HEAD" {
+			out = append(out, req.Method+" "+req.Path)
+		}
+	}
+	return out
+}
+
+func TestListIndices(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/_cat/indices", 200, `[
+		{"index":"logs-1","status":"open","health":"green","uuid":"u1","docs.count":"42","store.size":"1.5kb","pri.store.size":"512b","creation.date":"1700000000000"},
+		{"index":"logs-2","status":"close","creation.date.string":"2024-01-02T03:04:05Z"},
+		{"index":"logs-3","status":"open","docs.count":"","store.size":"2gb"}
+	]`)
+
+	indices, err := srv.Client().ListIndices(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 1 || reqs[0].Method != "GET" || reqs[0].Path != "/_cat/indices" {
+		t.Fatalf("unexpected requests: %+v", reqs)
+	}
+	if reqs[0].Query.Get("format") != "json" || !strings.Contains(reqs[0].Query.Get("h"), "creation.date") {
+		t.Errorf("unexpected query: %v", reqs[0].Query)
+	}
+
+	tests := []struct {
+		want opensearchmanager.IndexInfo
+	}{
+		{opensearchmanager.IndexInfo{
+			Name: "logs-1", Status: "open", Health: "green", UUID: "u1", DocsCount: 42,
+			StoreSize: "1.5kb", StoreSizeBytes: 1536, PrimaryStoreSizeBytes: 512,
+			CreateTime: time.UnixMilli(1700000000000).UTC(),
+		}},
+		{opensearchmanager.IndexInfo{
+			Name: "logs-2", Status: "close",
+			CreateTime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}},
+		{opensearchmanager.IndexInfo{
+			Name: "logs-3", Status: "open", StoreSize: "2gb", StoreSizeBytes: 2 << 30,
+		}},
+	}
+	if len(indices) != len(tests) {
+		t.Fatalf("got %d indices, want %d", len(indices), len(tests))
+	}
+	for i, tt := range tests {
+		if !reflect.DeepEqual(indices[i], tt.want) {
+			t.Errorf("index %d:\n got %+v\nwant %+v", i, indices[i], tt.want)
+		}
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	listing := catBody(
+		catRow("logs-2024.01.01", "open", "10mb", 400),
+		catRow("logs-2024.06.01", "open", "10mb", 200),
+		catRow("logs-new", "open", "10mb", 1),
+		catRow("logs-unknown", "open", "10mb", -1),
+		catRow("metrics-2020.01.01", "open", "10mb", 2000),
+		catRow(".logs-hidden", "open", "10mb", 2000),
+	)
+
+	tests := []struct {
+		name      string
+		dryRun    bool
+		protected []string
+		run       func(*opensearchmanager.Client) ([]string, error)
+		want      []string
+		wantReqs  []string
+	}{
+		{
+			name: "by age",
+			run: func(c *opensearchmanager.Client) ([]string, error) {
+				return c.CleanupByAge(context.Background(), "logs-", 30)
+			},
+			want:     []string{"logs-2024.01.01", "logs-2024.06.01"},
+			wantReqs: []string{"DELETE /logs-2024.01.01,logs-2024.06.01"},
+		},
+		{
+			name:   "by age dry run",
+			dryRun: true,
+			run: func(c *opensearchmanager.Client) ([]string, error) {
+				return c.CleanupByAge(context.Background(), "logs-", 30)
+			},
+			want: []string{"logs-2024.01.01", "logs-2024.06.01"},
+		},
+		{
+			name:      "by age protected",
+			protected: []string{"logs-2024.01*"},
+			run: func(c *opensearchmanager.Client) ([]string, error) {
+				return c.CleanupByAge(context.Background(), "logs-", 30)
+			},
+			want:     []string{"logs-2024.06.01"},
+			wantReqs: []string{"DELETE /logs-2024.06.01"},
+		},
+		{
+			name: "by age nothing to delete",
+			run: func(c *opensearchmanager.Client) ([]string, error) {
+				return c.CleanupByAge(context.Background(), "logs-", 1000)
+			},
+			want:     []string{},
+			wantReqs: nil,
+		},
+		{
+			name: "by size",
+			run: func(c *opensearchmanager.Client) ([]string, error) {
+				return c.CleanupBySize(context.Background(), "logs-", 25<<20)
+			},
+			want:     []string{"logs-2024.01.01"},
+			wantReqs: []string{"DELETE /logs-2024.01.01"},
+		},
+		{
+			name: "by count",
+			run: func(c *opensearchmanager.Client) ([]string, error) {
+				return c.CleanupByCount(context.Background(), "logs-", 1)
+			},
+			want:     []string{"logs-2024.06.01", "logs-2024.01.01"},
+			wantReqs: []string{"DELETE /logs-2024.06.01,logs-2024.01.01"},
+		},
+		{
+			name: "by name date",
+			run: func(c *opensearchmanager.Client) ([]string, error) {
+				return c.CleanupByNameDate(context.Background(), "logs-", "2006.01.02", 30)
+			},
+			want:     []string{"logs-2024.01.01", "logs-2024.06.01"},
+			wantReqs: []string{"DELETE /logs-2024.01.01,logs-2024.06.01"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle("GET", "/_cat/indices", 200, listing)
+			for _, req := range tt.wantReqs {
+				method, path, _ := strings.Cut(req, " ")
+				srv.Handle(method, path, 200, `{"acknowledged":true}`)
+			}
+
+			client := srv.Client()
+			client.DryRun = tt.dryRun
+			client.ProtectedPatterns = tt.protected
+
+			got, err := tt.run(client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 0 || len(tt.want) != 0 {
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("deleted %v, want %v", got, tt.want)
+				}
+			}
+			if reqs := writes(srv); !reflect.DeepEqual(reqs, tt.wantReqs) {
+				t.Errorf("requests %v, want %v", reqs, tt.wantReqs)
+			}
+		})
+	}
+}
+
+func TestDeleteAndCloseIndices(t *testing.T) {
+	listing := catBody(
+		catRow("logs-a", "open", "1mb", 1),
+		catRow("logs-b", "open", "1mb", 1),
+		catRow("logs-keep", "open", "1mb", 1),
+		catRow(".logs-hidden", "open", "1mb", 1),
+	)
+
+	del := func(c *opensearchmanager.Client, pattern string) ([]string, error) {
+		return c.DeleteIndices(context.Background(), pattern)
+	}
+	closeFn := func(c *opensearchmanager.Client, pattern string) ([]string, error) {
+		return c.CloseIndices(context.Background(), pattern)
+	}
+
+	tests := []struct {
+		name      string
+		op        func(*opensearchmanager.Client, string) ([]string, error)
+		pattern   string
+		dryRun    bool
+		protected []string
+		want      []string
+		wantErr   error
+		wantReqs  []string
+	}{
+		{
+			name:     "delete",
+			op:       del,
+			pattern:  "logs-*",
+			want:     []string{"logs-a", "logs-b", "logs-keep"},
+			wantReqs: []string{"DELETE /logs-a,logs-b,logs-keep"},
+		},
+		{
+			name:    "delete dry run",
+			op:      del,
+			pattern: "logs-*",
+			dryRun:  true,
+			want:    []string{"logs-a", "logs-b", "logs-keep"},
+		},
+		{
+			name:      "delete skips protected",
+			op:        del,
+			pattern:   "logs-*",
+			protected: []string{"logs-keep"},
+			want:      []string{"logs-a", "logs-b"},
+			wantReqs:  []string{"DELETE /logs-a,logs-b"},
+		},
+		{
+			name:      "delete protected by name",
+			op:        del,
+			pattern:   "logs-a,logs-keep",
+			protected: []string{"logs-keep"},
+			wantErr:   opensearchmanager.ErrProtectedIndex,
+		},
+		{
+			name:     "close",
+			op:       closeFn,
+			pattern:  "logs-*",
+			want:     []string{"logs-a", "logs-b", "logs-keep"},
+			wantReqs: []string{"POST /logs-a,logs-b,logs-keep/_close"},
+		},
+		{
+			name:    "close dry run",
+			op:      closeFn,
+			pattern: "logs-*",
+			dryRun:  true,
+			want:    []string{"logs-a", "logs-b", "logs-keep"},
+		},
+		{
+			name:      "close skips protected",
+			op:        closeFn,
+			pattern:   "logs-*",
+			protected: []string{"logs-k*"},
+			want:      []string{"logs-a", "logs-b"},
+			wantReqs:  []string{"POST /logs-a,logs-b/_close"},
+		},
+		{
+			name:      "close protected by name",
+			op:        closeFn,
+			pattern:   "logs-keep",
+			protected: []string{"logs-keep"},
+			wantErr:   opensearchmanager.ErrProtectedIndex,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle("GET", "/_cat/indices", 200, listing)
+			for _, req := range tt.wantReqs {
+				method, path, _ := strings.Cut(req, " ")
+				srv.Handle(method, path, 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+			}
+
+			client := srv.Client()
+			client.DryRun = tt.dryRun
+			client.ProtectedPatterns = tt.protected
+
+			got, err := tt.op(client, tt.pattern)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if reqs := writes(srv); !reflect.DeepEqual(reqs, tt.wantReqs) {
+				t.Errorf("requests %v, want %v", reqs, tt.wantReqs)
+			}
+		})
+	}
+}
+
+func TestOpenIndices(t *testing.T) {
+	listing := catBody(
+		catRow("logs-a", "open", "1mb", 1),
+		catRow("logs-b", "close", "", 1),
+		catRow("logs-c", "close", "", 1),
+		catRow(".logs-hidden", "close", "", 1),
+	)
+
+	tests := []struct {
+		name          string
+		excludeClosed bool
+		includeHidden bool
+		want          []string
+	}{
+		{"default", false, false, []string{"logs-b", "logs-c"}},
+		{"exclude closed", true, false, []string{"logs-b", "logs-c"}},
+		{"include hidden", true, true, []string{"logs-b", "logs-c", ".logs-hidden"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle("GET", "/_cat/indices", 200, listing)
+			wantReq := "/" + strings.Join(tt.want, ",") + "/_open"
+			srv.Handle("POST", wantReq, 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+
+			client := srv.Client()
+			client.ExcludeClosed = tt.excludeClosed
+			client.IncludeHidden = tt.includeHidden
+
+			got, err := client.OpenIndices(context.Background(), "*logs-*")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("opened %v, want %v", got, tt.want)
+			}
+			if reqs := writes(srv); !reflect.DeepEqual(reqs, []string{"POST " + wantReq}) {
+				t.Errorf("requests %v, want POST %s", reqs, wantReq)
+			}
+		})
+	}
+}
+
+func TestCloneCopiesSlices(t *testing.T) {
+	client := opensearchmanager.NewClient("http://localhost:9200", "admin", "admin")
+	client.ProtectedPatterns = []string{".kibana*", ".opendistro*"}
+	client.Retry = opensearchmanager.DefaultRetryPolicy()
+
+	clone := client.Clone()
+	clone.ProtectedPatterns[0] = "logs-*"
+	clone.ProtectedPatterns = append(clone.ProtectedPatterns[:1], "metrics-*")
+	clone.Retry.RetryableStatusCodes[0] = 500
+
+	if want := []string{".kibana*", ".opendistro*"}; !reflect.DeepEqual(client.ProtectedPatterns, want) {
+		t.Errorf("original ProtectedPatterns changed: %v", client.ProtectedPatterns)
+	}
+	if client.Retry.RetryableStatusCodes[0] == 500 {
+		t.Error("original RetryableStatusCodes changed")
+	}
+}
+
+func TestIndicesRegexAnchored(t *testing.T) {
+	listing := catBody(
+		catRow("logs-2023.05", "open", "1mb", 1),
+		catRow("logs-2023.010", "open", "1mb", 1),
+		catRow("app-logs-2023.05-archive", "open", "1mb", 1),
+	)
+
+	tests := []struct {
+		name     string
+		op       func(*opensearchmanager.Client) ([]string, error)
+		wantReqs []string
+	}{
+		{
+			name: "delete",
+			op: func(c *opensearchmanager.Client) ([]string, error) {
+				return c.DeleteIndicesRegex(context.Background(), `logs-2023\.(0[1-6])`)
+			},
+			wantReqs: []string{"DELETE /logs-2023.05"},
+		},
+		{
+			name: "close",
+			op: func(c *opensearchmanager.Client) ([]string, error) {
+				return c.CloseIndicesRegex(context.Background(), `logs-2023\.(0[1-6])`)
+			},
+			wantReqs: []string{"POST /logs-2023.05/_close"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle("GET", "/_cat/indices", 200, listing)
+			for _, req := range tt.wantReqs {
+				method, path, _ := strings.Cut(req, " ")
+				srv.Handle(method, path, 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+			}
+
+			got, err := tt.op(srv.Client())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []string{"logs-2023.05"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if reqs := writes(srv); !reflect.DeepEqual(reqs, tt.wantReqs) {
+				t.Errorf("requests %v, want %v", reqs, tt.wantReqs)
+			}
+		})
+	}
+}
+
+func TestNotAcknowledged(t *testing.T) {
+	actions := []opensearchmanager.AliasAction{{Add: map[string]interface{}{"index": "logs-1", "alias": "logs"}}}
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		call   func(*opensearchmanager.Client) error
+	}{
+		{"aliases", "POST", "/_aliases", func(c *opensearchmanager.Client) error {
+			return c.ManageAliases(context.Background(), actions)
+		}},
+		{"settings", "PUT", "/logs-1/_settings", func(c *opensearchmanager.Client) error {
+			return c.UpdateIndexSettings(context.Background(), "logs-1", map[string]interface{}{"index.number_of_replicas": 0})
+		}},
+		{"open", "POST", "/logs-1/_open", func(c *opensearchmanager.Client) error {
+			return c.OpenIndex(context.Background(), "logs-1")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, body := range []string{`{"acknowledged":false}`, `{"acknowledged":true,"shards_acknowledged":false}`} {
+				srv := opensearchtest.NewServer()
+				srv.Handle(tt.method, tt.path, 200, body)
+				if err := tt.call(srv.Client()); !errors.Is(err, opensearchmanager.ErrNotAcknowledged) {
+					t.Errorf("%s: got %v, want ErrNotAcknowledged", body, err)
+				}
+				srv.Close()
+			}
+
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle(tt.method, tt.path, 200, `{"acknowledged":true}`)
+			if err := tt.call(srv.Client()); err != nil {
+				t.Errorf("acknowledged: got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DanglingIndex representa um índice presente em disco nos nós mas ausente
+// do estado do cluster
+type DanglingIndex struct {
+	Name         string
+	UUID         string
+	CreationDate time.Time
+	NodeIDs      []string
+}
+
+// ListDanglingIndices lista os índices dangling reportados em /_dangling
+func (c *Client) ListDanglingIndices(ctx context.Context) ([]DanglingIndex, error) {
+	var payload struct {
+		DanglingIndices []struct {
+			IndexName          string   `json:"index_name"`
+			IndexUUID          string   `json:"index_uuid"`
+			CreationDateMillis int64    `json:"creation_date_millis"`
+			NodeIDs            []string `json:"node_ids"`
+		} `json:"dangling_indices"`
+	}
+
+	if err := c.call(ctx, "GET", "/_dangling", nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to list dangling indices: %w", err)
+	}
+
+	result := make([]DanglingIndex, 0, len(payload.DanglingIndices))
+	for _, idx := range payload.DanglingIndices {
+		result = append(result, DanglingIndex{
+			Name:         idx.IndexName,
+			UUID:         idx.IndexUUID,
+			CreationDate: time.UnixMilli(idx.CreationDateMillis),
+			NodeIDs:      idx.NodeIDs,
+		})
+	}
+
+	return result, nil
+}
+
+// ImportDanglingIndex importa um índice dangling de volta para o cluster. A
+// API exige acceptDataLoss=true, pois o conteúdo pode estar desatualizado.
+func (c *Client) ImportDanglingIndex(ctx context.Context, uuid string, acceptDataLoss bool) error {
+	path := fmt.Sprintf("/_dangling/%s?accept_data_loss=%s", uuid, strconv.FormatBool(acceptDataLoss))
+	if err := c.call(ctx, "POST", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to import dangling index: %w", err)
+	}
+	return nil
+}
+
+// DeleteDanglingIndex remove um índice dangling dos discos dos nós. A API
+// exige acceptDataLoss=true.
+func (c *Client) DeleteDanglingIndex(ctx context.Context, uuid string, acceptDataLoss bool) error {
+	path := fmt.Sprintf("/_dangling/%s?accept_data_loss=%s", uuid, strconv.FormatBool(acceptDataLoss))
+	if err := c.call(ctx, "DELETE", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete dangling index: %w", err)
+	}
+	return nil
+}
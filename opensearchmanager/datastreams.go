@@ -0,0 +1,139 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DataStreamInfo representa um data stream e seus índices de apoio
+type DataStreamInfo struct {
+	Name           string
+	TimestampField string
+	// Indices são os índices de apoio (.ds-*) do mais antigo ao atual;
+	// o último é o índice de escrita
+	Indices    []string
+	Generation int
+	Status     string
+	Template   string
+}
+
+// CreateDataStream cria um data stream; requer um index template com
+// "data_stream" habilitado que corresponda ao nome
+func (c *Client) CreateDataStream(ctx context.Context, name string) error {
+	path := fmt.Sprintf("/_data_stream/%s", name)
+	if err := c.call(ctx, "PUT", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to create data stream: %w", err)
+	}
+	return nil
+}
+
+// ListDataStreams lista os data streams do cluster
+func (c *Client) ListDataStreams(ctx context.Context) ([]DataStreamInfo, error) {
+	var payload struct {
+		DataStreams []struct {
+			Name           string `json:"name"`
+			TimestampField struct {
+				Name string `json:"name"`
+			} `json:"timestamp_field"`
+			Indices []struct {
+				IndexName string `json:"index_name"`
+			} `json:"indices"`
+			Generation int    `json:"generation"`
+			Status     string `json:"status"`
+			Template   string `json:"template"`
+		} `json:"data_streams"`
+	}
+
+	if err := c.call(ctx, "GET", "/_data_stream", nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to list data streams: %w", err)
+	}
+
+	result := make([]DataStreamInfo, 0, len(payload.DataStreams))
+	for _, ds := range payload.DataStreams {
+		info := DataStreamInfo{
+			Name:           ds.Name,
+			TimestampField: ds.TimestampField.Name,
+			Generation:     ds.Generation,
+			Status:         ds.Status,
+			Template:       ds.Template,
+		}
+		for _, idx := range ds.Indices {
+			info.Indices = append(info.Indices, idx.IndexName)
+		}
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+// DeleteDataStream remove o data stream e todos os seus índices de apoio
+func (c *Client) DeleteDataStream(ctx context.Context, name string) error {
+	if err := c.checkProtectedNames([]string{name}); err != nil {
+		return err
+	}
+	if c.DryRun {
+		return nil
+	}
+
+	path := fmt.Sprintf("/_data_stream/%s", name)
+	if err := c.call(ctx, "DELETE", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete data stream: %w", err)
+	}
+	return nil
+}
+
+// CleanupDataStream remove os índices de apoio do data stream criados há mais
+// de N dias. Os índices de apoio são ocultos e por isso ficam fora das
+// rotinas de Cleanup por prefixo; o índice de escrita nunca é removido.
+// O rollover de um data stream é feito com Rollover usando o nome do stream.
+func (c *Client) CleanupDataStream(ctx context.Context, name string, days int) ([]string, error) {
+	streams, err := c.ListDataStreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var backing []string
+	found := false
+	for _, ds := range streams {
+		if ds.Name == name {
+			backing, found = ds.Indices, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("data stream %s not found", name)
+	}
+
+	toDelete := []string{}
+	if len(backing) < 2 {
+		return toDelete, nil
+	}
+
+	// O último índice de apoio é o índice de escrita
+	indices, err := c.ListIndicesMatching(ctx, strings.Join(backing[:len(backing)-1], ","))
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -days)
+	for _, idx := range indices {
+		if idx.CreateTime.IsZero() || c.isProtected(idx.Name) {
+			continue
+		}
+		if idx.CreateTime.Before(cutoff) {
+			toDelete = append(toDelete, idx.Name)
+		}
+	}
+
+	if len(toDelete) == 0 {
+		return toDelete, nil
+	}
+
+	if err := c.deleteIndexList(ctx, toDelete); err != nil {
+		return nil, err
+	}
+
+	return toDelete, nil
+}
@@ -0,0 +1,112 @@
+package opensearchmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError representa uma resposta de erro (status >= 400) do OpenSearch
+type APIError struct {
+	StatusCode int
+	Type       string
+	Reason     string
+	Raw        []byte
+}
+
+// Error implementa a interface error
+func (e *APIError) Error() string {
+	if e.Type != "" {
+		return fmt.Sprintf("status %d: %s: %s", e.StatusCode, e.Type, e.Reason)
+	}
+	return fmt.Sprintf("status %d: %s", e.StatusCode, string(e.Raw))
+}
+
+// newAPIError interpreta o formato padrão {"error":{"type":...,"reason":...}};
+// respostas fora desse formato ficam disponíveis apenas em Raw
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Raw: body}
+
+	var payload struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || len(payload.Error) == 0 {
+		return apiErr
+	}
+
+	var detail struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(payload.Error, &detail); err == nil {
+		apiErr.Type = detail.Type
+		apiErr.Reason = detail.Reason
+		return apiErr
+	}
+
+	// Algumas APIs (ex.: plugins) retornam "error" como string simples
+	var reason string
+	if err := json.Unmarshal(payload.Error, &reason); err == nil {
+		apiErr.Reason = reason
+	}
+	return apiErr
+}
+
+// ErrProtectedIndex é retornado quando uma operação destrutiva nomeia
+// explicitamente um índice coberto por ProtectedPatterns
+var ErrProtectedIndex = errors.New("index is protected")
+
+// ErrNotAcknowledged é retornado quando o cluster responde com sucesso mas
+// "acknowledged" (ou "shards_acknowledged") é false: a alteração pode não
+// ter sido aplicada dentro do timeout
+var ErrNotAcknowledged = errors.New("operation not acknowledged by the cluster")
+
+// errShardsNotAcknowledged é o ErrNotAcknowledged em que a alteração foi
+// aplicada, mas os shards não ficaram ativos dentro do timeout
+var errShardsNotAcknowledged = fmt.Errorf("%w: shards not acknowledged", ErrNotAcknowledged)
+
+// shardsPending indica que a operação só falhou por shards_acknowledged:false
+func shardsPending(err error) bool {
+	return errors.Is(err, errShardsNotAcknowledged)
+}
+
+// IsNotFound indica se o erro é um 404 do OpenSearch
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized indica se o erro é um 401 do OpenSearch
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden indica se o erro é um 403 do OpenSearch
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsConflict indica se o erro é um 409 do OpenSearch
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsIndexNotFound indica se o erro é um index_not_found_exception
+func IsIndexNotFound(err error) bool {
+	return hasType(err, "index_not_found_exception")
+}
+
+// IsIndexAlreadyExists indica se o erro é um resource_already_exists_exception
+func IsIndexAlreadyExists(err error) bool {
+	return hasType(err, "resource_already_exists_exception")
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+func hasType(err error, errType string) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Type == errType
+}
@@ -0,0 +1,337 @@
+package opensearchmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ForceMerge executa um force-merge nos índices que correspondem ao padrão.
+//
+// Um merge grande pode levar bem mais que o RequestTimeout padrão de 30s;
+// nesses casos passe um contexto com prazo adequado, que tem precedência.
+func (c *Client) ForceMerge(ctx context.Context, indexPattern string, maxNumSegments int, onlyExpungeDeletes bool, opts ...RequestOption) error {
+	// Índices fechados não podem ser mesclados
+	indices, err := c.matchIndices(ctx, indexPattern, withStatus(globMatcher(indexPattern), IndexStatusOpen))
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	if maxNumSegments > 0 {
+		params.Set("max_num_segments", strconv.Itoa(maxNumSegments))
+	}
+	if onlyExpungeDeletes {
+		params.Set("only_expunge_deletes", "true")
+	}
+
+	path := fmt.Sprintf("/%s/_forcemerge", strings.Join(indices, ","))
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	path = withRequestOptions(path, opts)
+
+	if err := c.call(ctx, "POST", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to force merge indices: %w", err)
+	}
+
+	return nil
+}
+
+// Flush persiste o translog dos índices que correspondem ao padrão em
+// /{indices}/_flush. Recomendado antes de snapshots e shrinks.
+func (c *Client) Flush(ctx context.Context, indexPattern string, force, waitIfOngoing bool) error {
+	// Índices fechados não podem receber flush
+	indices, err := c.matchIndices(ctx, indexPattern, withStatus(globMatcher(indexPattern), IndexStatusOpen))
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	if force {
+		params.Set("force", "true")
+	}
+	if waitIfOngoing {
+		params.Set("wait_if_ongoing", "true")
+	}
+
+	path := fmt.Sprintf("/%s/_flush", strings.Join(indices, ","))
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	if err := c.call(ctx, "POST", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to flush indices: %w", err)
+	}
+
+	return nil
+}
+
+// Refresh torna visíveis para busca as operações recentes dos índices que
+// correspondem ao padrão.
+//
+// Em cargas volumosas é comum desativar o refresh no índice de destino,
+// carregar os dados e então restaurá-lo antes de um Refresh final:
+//
+//	client.UpdateIndexSettings(ctx, dest, map[string]interface{}{"index.refresh_interval": "-1"})
+//	// ... carga ...
+//	client.UpdateIndexSettings(ctx, dest, map[string]interface{}{"index.refresh_interval": nil})
+//	client.Refresh(ctx, dest)
+//
+// ReindexOptions.DisableRefresh aplica esse padrão automaticamente.
+func (c *Client) Refresh(ctx context.Context, indexPattern string) error {
+	indices, err := c.matchIndices(ctx, indexPattern, withStatus(globMatcher(indexPattern), IndexStatusOpen))
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/%s/_refresh", strings.Join(indices, ","))
+	if err := c.call(ctx, "POST", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to refresh indices: %w", err)
+	}
+
+	return nil
+}
+
+// CloneIndex cria uma cópia do índice source em target usando a API _clone.
+// O source recebe bloqueio de escrita durante a operação e volta ao bloqueio
+// que tinha antes; o novo índice fica gravável.
+func (c *Client) CloneIndex(ctx context.Context, source, target string, settings map[string]interface{}) error {
+	// 1. Registrar o bloqueio original e bloquear escrita no índice fonte
+	original, err := c.GetIndexSettings(ctx, source)
+	if err != nil {
+		return fmt.Errorf("failed to read source settings: %w", err)
+	}
+	if err := c.SetIndexBlock(ctx, source, "write", true); err != nil {
+		return fmt.Errorf("failed to block writes on source index: %w", err)
+	}
+
+	// 2. Executar o clone
+	cloneErr := c.cloneRequest(ctx, source, target, settings)
+
+	// 3. Restaurar o bloqueio original do source, mesmo se o clone falhou
+	if err := c.restoreWriteBlock(ctx, source, original); err != nil {
+		if cloneErr != nil {
+			return fmt.Errorf("%w (also failed to restore source write block: %v)", cloneErr, err)
+		}
+		return fmt.Errorf("failed to restore source write block: %w", err)
+	}
+	if cloneErr != nil {
+		return cloneErr
+	}
+
+	// 4. Aguardar o novo índice ficar pronto
+	if err := c.WaitForGreen(ctx, target, readyTimeout); err != nil {
+		return fmt.Errorf("cloned index not ready: %w", err)
+	}
+
+	// 5. O clone herda o bloqueio do source; removê-lo do novo índice
+	if err := c.SetIndexBlock(ctx, target, "write", false); err != nil {
+		return fmt.Errorf("failed to clear write block on target index: %w", err)
+	}
+
+	return nil
+}
+
+// restoreWriteBlock devolve ao índice o index.blocks.write lido antes da
+// operação; um valor ausente remove o bloqueio, restaurando o padrão
+func (c *Client) restoreWriteBlock(ctx context.Context, index string, original map[string]interface{}) error {
+	return c.UpdateIndexSettings(ctx, index, map[string]interface{}{
+		"index.blocks.write": original["index.blocks.write"],
+	})
+}
+
+// cloneRequest envia a requisição de clone propriamente dita
+func (c *Client) cloneRequest(ctx context.Context, source, target string, settings map[string]interface{}) error {
+	body := map[string]interface{}{}
+	if len(settings) > 0 {
+		body["settings"] = settings
+	}
+
+	path := c.withActiveShards(fmt.Sprintf("/%s/_clone/%s", source, target))
+	if err := c.call(ctx, "POST", path, body, nil); err != nil {
+		return fmt.Errorf("clone failed: %w", err)
+	}
+
+	return nil
+}
+
+// SplitIndex divide o índice source em target com mais shards. O número de
+// shards de destino precisa ser múltiplo do número atual de shards do source.
+// O source volta ao bloqueio de escrita que tinha antes da operação.
+func (c *Client) SplitIndex(ctx context.Context, source, target string, numberOfShards int, settings map[string]interface{}) error {
+	// 1. Registrar o bloqueio original e validar o número de shards antes
+	// de qualquer alteração
+	original, err := c.GetIndexSettings(ctx, source)
+	if err != nil {
+		return fmt.Errorf("failed to read source settings: %w", err)
+	}
+	sourceShards, err := shardCount(source, original)
+	if err != nil {
+		return err
+	}
+	if numberOfShards <= sourceShards || numberOfShards%sourceShards != 0 {
+		return fmt.Errorf("cannot split %d shards into %d: target must be a multiple of the source shard count", sourceShards, numberOfShards)
+	}
+
+	// 2. Bloquear escrita no índice fonte
+	if err := c.SetIndexBlock(ctx, source, "write", true); err != nil {
+		return fmt.Errorf("failed to block writes on source index: %w", err)
+	}
+
+	// 3. Executar o split
+	splitErr := c.splitRequest(ctx, source, target, mergeSettings(settings, map[string]interface{}{
+		"index.number_of_shards": numberOfShards,
+	}))
+
+	// 4. Restaurar o bloqueio original do source, mesmo se o split falhou
+	if err := c.restoreWriteBlock(ctx, source, original); err != nil {
+		if splitErr != nil {
+			return fmt.Errorf("%w (also failed to restore source write block: %v)", splitErr, err)
+		}
+		return fmt.Errorf("failed to restore source write block: %w", err)
+	}
+	if splitErr != nil {
+		return splitErr
+	}
+
+	// 5. O novo índice herda o bloqueio do source; removê-lo
+	if err := c.SetIndexBlock(ctx, target, "write", false); err != nil {
+		return fmt.Errorf("failed to clear write block on target index: %w", err)
+	}
+
+	return nil
+}
+
+// splitRequest envia a requisição de split propriamente dita
+func (c *Client) splitRequest(ctx context.Context, source, target string, settings map[string]interface{}) error {
+	body := map[string]interface{}{"settings": settings}
+
+	path := c.withActiveShards(fmt.Sprintf("/%s/_split/%s", source, target))
+	if err := c.call(ctx, "POST", path, body, nil); err != nil {
+		return fmt.Errorf("split failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetIndexSettings retorna as configurações de um índice em formato plano,
+// com as mesmas chaves aceitas por UpdateIndexSettings
+// (ex.: "index.number_of_shards", "index.blocks.write")
+func (c *Client) GetIndexSettings(ctx context.Context, index string) (map[string]interface{}, error) {
+	var payload map[string]struct {
+		Settings map[string]interface{} `json:"settings"`
+	}
+
+	path := fmt.Sprintf("/%s/_settings?flat_settings=true", index)
+	if err := c.call(ctx, "GET", path, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to get index settings: %w", err)
+	}
+
+	if entry, ok := payload[index]; ok {
+		return entry.Settings, nil
+	}
+	// Um alias resolve para o nome concreto do índice
+	if len(payload) == 1 {
+		for _, entry := range payload {
+			return entry.Settings, nil
+		}
+	}
+	return nil, fmt.Errorf("index %s not found in settings response", index)
+}
+
+// shardCount lê o número de shards primários das configurações de um índice
+func shardCount(index string, settings map[string]interface{}) (int, error) {
+	value := fmt.Sprint(settings["index.number_of_shards"])
+	shards, err := strconv.Atoi(value)
+	if err != nil || shards < 1 {
+		return 0, fmt.Errorf("invalid number_of_shards for index %s: %q", index, value)
+	}
+
+	return shards, nil
+}
+
+// CreateIndexRequest representa o corpo de criação de um índice
+type CreateIndexRequest struct {
+	Settings map[string]interface{} `json:"settings,omitempty"`
+	Mappings map[string]interface{} `json:"mappings,omitempty"`
+	Aliases  map[string]interface{} `json:"aliases,omitempty"`
+}
+
+// CreateIndex cria um índice com settings, mappings e aliases. Se o índice
+// já existir o erro retornado satisfaz IsIndexAlreadyExists.
+func (c *Client) CreateIndex(ctx context.Context, name string, body CreateIndexRequest) error {
+	path := c.withActiveShards(fmt.Sprintf("/%s", name))
+	if err := c.call(ctx, "PUT", path, body, nil); err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+	return nil
+}
+
+// FreezeIndex congela um índice para reduzir o uso de heap, mantendo-o pesquisável
+func (c *Client) FreezeIndex(ctx context.Context, index string) error {
+	return c.freezeRequest(ctx, index, "_freeze")
+}
+
+// UnfreezeIndex descongela um índice previamente congelado
+func (c *Client) UnfreezeIndex(ctx context.Context, index string) error {
+	return c.freezeRequest(ctx, index, "_unfreeze")
+}
+
+// freezeRequest envia _freeze/_unfreeze destacando quando a API não é suportada
+func (c *Client) freezeRequest(ctx context.Context, index, endpoint string) error {
+	path := fmt.Sprintf("/%s/%s", index, endpoint)
+	err := c.call(ctx, "POST", path, nil, nil)
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && !IsIndexNotFound(err) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest, http.StatusNotFound, http.StatusMethodNotAllowed:
+			return fmt.Errorf("%s is not supported by this cluster version: %w", endpoint, err)
+		}
+	}
+	return fmt.Errorf("failed to %s index: %w", strings.TrimPrefix(endpoint, "_"), err)
+}
+
+// IndexExists verifica a existência do índice com uma requisição HEAD
+func (c *Client) IndexExists(ctx context.Context, index string) (bool, error) {
+	exists, err := c.exists(ctx, fmt.Sprintf("/%s", index))
+	if err != nil {
+		return false, fmt.Errorf("failed to check index: %w", err)
+	}
+	return exists, nil
+}
+
+// SetAllocation aplica filtros de alocação de shards (ex.: mover índices
+// antigos para nós warm com {"box_type": "warm"}) aos índices que
+// correspondem ao padrão. allocType é require, include ou exclude; um valor
+// vazio remove o filtro do atributo.
+func (c *Client) SetAllocation(ctx context.Context, indexPattern string, attributes map[string]string, allocType string) error {
+	switch allocType {
+	case "require", "include", "exclude":
+	default:
+		return fmt.Errorf("unknown allocation type: %s", allocType)
+	}
+	if len(attributes) == 0 {
+		return fmt.Errorf("no allocation attributes to set")
+	}
+
+	settings := make(map[string]interface{}, len(attributes))
+	for attr, value := range attributes {
+		key := fmt.Sprintf("index.routing.allocation.%s.%s", allocType, attr)
+		if value == "" {
+			settings[key] = nil
+		} else {
+			settings[key] = value
+		}
+	}
+
+	return c.UpdateIndexSettingsMatching(ctx, indexPattern, settings)
+}
@@ -0,0 +1,83 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+// settingsBodies retorna os corpos enviados para PUT path, em ordem
+func settingsBodies(srv *opensearchtest.Server, path string) []string {
+	var out []string
+	for _, req := range srv.Requests() {
+		if req.Method == "PUT" && req.Path == path {
+			out = append(out, string(req.Body))
+		}
+	}
+	return out
+}
+
+func TestCloneIndexRestoresWriteBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		restore  string
+	}{
+		{"writable source", `{"logs-1":{"settings":{}}}`, `{"settings":{"index.blocks.write":null}}`},
+		{"read-only source", `{"logs-1":{"settings":{"index.blocks.write":"true"}}}`, `{"settings":{"index.blocks.write":"true"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle("GET", "/logs-1/_settings", 200, tt.settings)
+			srv.Handle("PUT", "/logs-1/_settings", 200, `{"acknowledged":true}`)
+			srv.Handle("POST", "/logs-1/_clone/logs-2", 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+			srv.Handle("GET", "/_cluster/health/logs-2", 200, `{"status":"green"}`)
+			srv.Handle("PUT", "/logs-2/_settings", 200, `{"acknowledged":true}`)
+
+			if err := srv.Client().CloneIndex(context.Background(), "logs-1", "logs-2", nil); err != nil {
+				t.Fatal(err)
+			}
+
+			want := []string{`{"settings":{"index.blocks.write":true}}`, tt.restore}
+			if got := settingsBodies(srv, "/logs-1/_settings"); !reflect.DeepEqual(got, want) {
+				t.Errorf("source settings %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSplitIndexRestoresWriteBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		restore  string
+	}{
+		{"writable source", `{"logs-1":{"settings":{"index.number_of_shards":"1"}}}`, `{"settings":{"index.blocks.write":null}}`},
+		{"read-only source", `{"logs-1":{"settings":{"index.number_of_shards":"1","index.blocks.write":"true"}}}`, `{"settings":{"index.blocks.write":"true"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle("GET", "/logs-1/_settings", 200, tt.settings)
+			srv.Handle("PUT", "/logs-1/_settings", 200, `{"acknowledged":true}`)
+			srv.Handle("POST", "/logs-1/_split/logs-2", 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+			srv.Handle("PUT", "/logs-2/_settings", 200, `{"acknowledged":true}`)
+
+			if err := srv.Client().SplitIndex(context.Background(), "logs-1", "logs-2", 2, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			want := []string{`{"settings":{"index.blocks.write":true}}`, tt.restore}
+			if got := settingsBodies(srv, "/logs-1/_settings"); !reflect.DeepEqual(got, want) {
+				t.Errorf("source settings %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package opensearchmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ISMPolicy representa uma policy do Index State Management
+type ISMPolicy struct {
+	ID          string
+	Version     int64
+	SeqNo       int64
+	PrimaryTerm int64
+	Policy      map[string]interface{}
+}
+
+// GetISMPolicy retorna uma policy ISM com os metadados de controle de concorrência
+func (c *Client) GetISMPolicy(ctx context.Context, policyID string) (*ISMPolicy, error) {
+	var payload struct {
+		ID          string                 `json:"_id"`
+		Version     int64                  `json:"_version"`
+		SeqNo       int64                  `json:"_seq_no"`
+		PrimaryTerm int64                  `json:"_primary_term"`
+		Policy      map[string]interface{} `json:"policy"`
+	}
+
+	path := fmt.Sprintf("/_plugins/_ism/policies/%s", policyID)
+	if err := c.call(ctx, "GET", path, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to get ISM policy: %w", err)
+	}
+
+	return &ISMPolicy{
+		ID:          payload.ID,
+		Version:     payload.Version,
+		SeqNo:       payload.SeqNo,
+		PrimaryTerm: payload.PrimaryTerm,
+		Policy:      payload.Policy,
+	}, nil
+}
+
+// CreateISMPolicy cria uma policy ISM. policy é o conteúdo do objeto
+// "policy" (description, states, ...). Se a policy já existir o erro
+// satisfaz IsConflict; para alterá-la use UpdateISMPolicy.
+func (c *Client) CreateISMPolicy(ctx context.Context, policyID string, policy map[string]interface{}) error {
+	path := fmt.Sprintf("/_plugins/_ism/policies/%s", policyID)
+	body := map[string]interface{}{"policy": policy}
+	if err := c.call(ctx, "PUT", path, body, nil); err != nil {
+		return fmt.Errorf("failed to create ISM policy: %w", err)
+	}
+	return nil
+}
+
+// UpdateISMPolicy grava policy.Policy usando o _seq_no e o _primary_term
+// lidos pelo chamador em GetISMPolicy. Se a policy foi alterada por outro
+// processo desde essa leitura, o erro satisfaz IsConflict e nada é gravado.
+func (c *Client) UpdateISMPolicy(ctx context.Context, policy *ISMPolicy) error {
+	if policy.ID == "" {
+		return fmt.Errorf("ISM policy ID is required")
+	}
+
+	params := url.Values{}
+	params.Set("if_seq_no", strconv.FormatInt(policy.SeqNo, 10))
+	params.Set("if_primary_term", strconv.FormatInt(policy.PrimaryTerm, 10))
+	path := fmt.Sprintf("/_plugins/_ism/policies/%s?%s", policy.ID, params.Encode())
+
+	body := map[string]interface{}{"policy": policy.Policy}
+	if err := c.call(ctx, "PUT", path, body, nil); err != nil {
+		return fmt.Errorf("failed to update ISM policy: %w", err)
+	}
+	return nil
+}
+
+// DeleteISMPolicy remove uma policy ISM
+func (c *Client) DeleteISMPolicy(ctx context.Context, policyID string) error {
+	path := fmt.Sprintf("/_plugins/_ism/policies/%s", policyID)
+	if err := c.call(ctx, "DELETE", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete ISM policy: %w", err)
+	}
+	return nil
+}
+
+// ismChangeResult representa a resposta das APIs add/remove do ISM
+type ismChangeResult struct {
+	UpdatedIndices int  `json:"updated_indices"`
+	Failures       bool `json:"failures"`
+	FailedIndices  []struct {
+		IndexName string `json:"index_name"`
+		Reason    string `json:"reason"`
+	} `json:"failed_indices"`
+}
+
+// err converte as falhas por índice em um erro
+func (r ismChangeResult) err() error {
+	if !r.Failures || len(r.FailedIndices) == 0 {
+		return nil
+	}
+	var reasons []string
+	for _, f := range r.FailedIndices {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", f.IndexName, f.Reason))
+	}
+	return fmt.Errorf("%d indices failed: %s", len(r.FailedIndices), strings.Join(reasons, "; "))
+}
+
+// AttachISMPolicy associa uma policy ISM aos índices que correspondem ao padrão
+func (c *Client) AttachISMPolicy(ctx context.Context, indexPattern, policyID string) error {
+	body := map[string]interface{}{"policy_id": policyID}
+
+	var result ismChangeResult
+	path := fmt.Sprintf("/_plugins/_ism/add/%s", indexPattern)
+	if err := c.call(ctx, "POST", path, body, &result); err != nil {
+		return fmt.Errorf("failed to attach ISM policy: %w", err)
+	}
+	if err := result.err(); err != nil {
+		return fmt.Errorf("failed to attach ISM policy: %w", err)
+	}
+	return nil
+}
+
+// DetachISMPolicy remove a policy ISM dos índices que correspondem ao padrão
+func (c *Client) DetachISMPolicy(ctx context.Context, indexPattern string) error {
+	var result ismChangeResult
+	path := fmt.Sprintf("/_plugins/_ism/remove/%s", indexPattern)
+	if err := c.call(ctx, "POST", path, nil, &result); err != nil {
+		return fmt.Errorf("failed to detach ISM policy: %w", err)
+	}
+	if err := result.err(); err != nil {
+		return fmt.Errorf("failed to detach ISM policy: %w", err)
+	}
+	return nil
+}
+
+// ISMIndexState representa o estado de gerenciamento ISM de um índice
+type ISMIndexState struct {
+	Index     string
+	PolicyID  string
+	Enabled   bool
+	State     string
+	Action    string
+	Failed    bool
+	Message   string
+	StartTime time.Time
+}
+
+// ExplainISM retorna o estado ISM atual dos índices, útil para entender
+// por que uma policy não está progredindo
+func (c *Client) ExplainISM(ctx context.Context, index string) ([]ISMIndexState, error) {
+	var payload map[string]json.RawMessage
+
+	path := fmt.Sprintf("/_plugins/_ism/explain/%s", index)
+	if err := c.call(ctx, "GET", path, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to explain ISM: %w", err)
+	}
+
+	var result []ISMIndexState
+	for name, raw := range payload {
+		var entry struct {
+			PolicyID string `json:"policy_id"`
+			Enabled  *bool  `json:"enabled"`
+			State    struct {
+				Name      string `json:"name"`
+				StartTime int64  `json:"start_time"`
+			} `json:"state"`
+			Action struct {
+				Name   string `json:"name"`
+				Failed bool   `json:"failed"`
+			} `json:"action"`
+			Info struct {
+				Message string `json:"message"`
+			} `json:"info"`
+		}
+		// Entradas como "total_managed_indices" não são objetos de índice
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+
+		state := ISMIndexState{
+			Index:    name,
+			PolicyID: entry.PolicyID,
+			Enabled:  entry.Enabled != nil && *entry.Enabled,
+			State:    entry.State.Name,
+			Action:   entry.Action.Name,
+			Failed:   entry.Action.Failed,
+			Message:  entry.Info.Message,
+		}
+		if entry.State.StartTime > 0 {
+			state.StartTime = time.UnixMilli(entry.State.StartTime)
+		}
+		result = append(result, state)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Index < result[j].Index
+	})
+
+	return result, nil
+}
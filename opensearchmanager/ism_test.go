@@ -0,0 +1,75 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"testing"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager"
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+const ismPath = "/_plugins/_ism/policies/hot-warm"
+
+func TestGetAndUpdateISMPolicy(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", ismPath, 200, `{"_id":"hot-warm","_version":3,"_seq_no":7,"_primary_term":2,"policy":{"description":"v3"}}`)
+	srv.Handle("PUT", ismPath, 200, `{"_id":"hot-warm","_version":4}`)
+
+	client := srv.Client()
+	policy, err := client.GetISMPolicy(context.Background(), "hot-warm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.ID != "hot-warm" || policy.Version != 3 || policy.SeqNo != 7 || policy.PrimaryTerm != 2 || policy.Policy["description"] != "v3" {
+		t.Fatalf("unexpected policy: %+v", policy)
+	}
+
+	policy.Policy["description"] = "v4"
+	if err := client.UpdateISMPolicy(context.Background(), policy); err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 2 {
+		t.Fatalf("got %d requests, want GET and PUT only", len(reqs))
+	}
+	put := reqs[1]
+	if put.Method != "PUT" || put.Query.Get("if_seq_no") != "7" || put.Query.Get("if_primary_term") != "2" {
+		t.Errorf("unexpected update request: %s %s?%s", put.Method, put.Path, put.Query.Encode())
+	}
+	if string(put.Body) != `{"policy":{"description":"v4"}}` {
+		t.Errorf("unexpected update body: %s", put.Body)
+	}
+}
+
+func TestUpdateISMPolicyConflict(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("PUT", ismPath, 409, `{"error":{"type":"version_conflict_engine_exception","reason":"seq_no mismatch"},"status":409}`)
+
+	// Metadados de uma leitura anterior, já superada por outra alteração
+	stale := &opensearchmanager.ISMPolicy{ID: "hot-warm", SeqNo: 7, PrimaryTerm: 2, Policy: map[string]interface{}{}}
+	err := srv.Client().UpdateISMPolicy(context.Background(), stale)
+	if !opensearchmanager.IsConflict(err) {
+		t.Fatalf("got error %v, want conflict", err)
+	}
+	if reqs := srv.Requests(); len(reqs) != 1 {
+		t.Errorf("got %d requests, want a single PUT", len(reqs))
+	}
+}
+
+func TestCreateISMPolicy(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("PUT", ismPath, 201, `{"_id":"hot-warm","_version":1}`)
+
+	if err := srv.Client().CreateISMPolicy(context.Background(), "hot-warm", map[string]interface{}{"description": "v1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 1 || len(reqs[0].Query) != 0 {
+		t.Errorf("create must be a single PUT without seq_no: %+v", reqs)
+	}
+}
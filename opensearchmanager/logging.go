@@ -0,0 +1,55 @@
+package opensearchmanager
+
+import (
+	"context"
+	"time"
+)
+
+// LogEvent descreve uma tentativa de requisição ao OpenSearch. Nunca contém
+// credenciais nem o corpo da requisição.
+type LogEvent struct {
+	Method     string
+	Path       string
+	StatusCode int // zero quando a requisição falhou antes de haver resposta
+	Duration   time.Duration
+	Attempt    int
+	Err        error
+}
+
+// Logger recebe um evento por tentativa de requisição
+type Logger interface {
+	Log(ctx context.Context, event LogEvent)
+}
+
+// nopLogger é o logger padrão, que descarta os eventos
+type nopLogger struct{}
+
+func (nopLogger) Log(context.Context, LogEvent) {}
+
+// logger retorna o logger configurado ou o no-op padrão
+func (c *Client) logger() Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	return nopLogger{}
+}
+
+// Observer recebe métricas de cada tentativa de requisição (ex.: contadores
+// por método/status e histogramas de latência). status é zero quando a
+// requisição falhou antes de haver resposta.
+type Observer interface {
+	ObserveRequest(method, path string, status int, dur time.Duration)
+}
+
+// nopObserver é o observer padrão, que descarta as métricas
+type nopObserver struct{}
+
+func (nopObserver) ObserveRequest(string, string, int, time.Duration) {}
+
+// observer retorna o observer configurado ou o no-op padrão
+func (c *Client) observer() Observer {
+	if c.Observer != nil {
+		return c.Observer
+	}
+	return nopObserver{}
+}
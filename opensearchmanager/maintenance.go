@@ -0,0 +1,131 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+)
+
+// MaintenancePlan descreve a rotina de manutenção executada por
+// RunMaintenance: rollover, force-merge e retenção, nessa ordem. Etapas
+// nil são puladas.
+type MaintenancePlan struct {
+	Rollover   *RolloverStep
+	ForceMerge *ForceMergeStep
+	Retention  *RetentionStep
+}
+
+// RolloverStep faz o rollover do alias de escrita se as condições forem atendidas
+type RolloverStep struct {
+	Alias      string
+	Conditions map[string]interface{}
+}
+
+// ForceMergeStep mescla os segmentos de um índice ou padrão
+type ForceMergeStep struct {
+	// Pattern alvo do force-merge; vazio usa o índice anterior do rollover
+	// (a etapa é pulada se não houve rollover)
+	Pattern            string
+	MaxNumSegments     int
+	OnlyExpungeDeletes bool
+}
+
+// RetentionStep remove índices com o prefixo mais antigos que Days dias
+type RetentionStep struct {
+	Prefix string
+	Days   int
+}
+
+// Nomes das etapas em StepReport.Name
+const (
+	StepRollover   = "rollover"
+	StepForceMerge = "forcemerge"
+	StepRetention  = "retention"
+)
+
+// StepReport registra o resultado de uma etapa da manutenção
+type StepReport struct {
+	Name    string
+	Skipped bool
+	// Indices afetados pela etapa (novo índice, alvo do merge ou removidos)
+	Indices []string
+	Err     error
+}
+
+// MaintenanceReport reúne o resultado de cada etapa executada
+type MaintenanceReport struct {
+	Steps []StepReport
+	// Rollover traz a resposta do rollover, quando a etapa foi executada
+	Rollover *RolloverResult
+}
+
+// RunMaintenance executa o plano etapa a etapa e interrompe na primeira
+// falha, retornando o relatório parcial junto com o erro. Em DryRun o
+// rollover é apenas avaliado, o force-merge é pulado e a retenção apenas
+// calcula os índices que seriam removidos.
+func (c *Client) RunMaintenance(ctx context.Context, plan MaintenancePlan) (*MaintenanceReport, error) {
+	// Validado antes de qualquer etapa: zero dias removeria todo o prefixo
+	if plan.Retention != nil && plan.Retention.Days <= 0 {
+		return nil, fmt.Errorf("retention days must be positive, got %d", plan.Retention.Days)
+	}
+
+	report := &MaintenanceReport{}
+
+	// 1. Rollover
+	if plan.Rollover == nil {
+		report.Steps = append(report.Steps, StepReport{Name: StepRollover, Skipped: true})
+	} else {
+		result, err := c.RolloverWithOptions(ctx, plan.Rollover.Alias, plan.Rollover.Conditions, RolloverOptions{DryRun: c.DryRun})
+		step := StepReport{Name: StepRollover, Err: err}
+		if err == nil {
+			report.Rollover = result
+			if result.RolledOver {
+				step.Indices = []string{result.NewIndex}
+			}
+		}
+		report.Steps = append(report.Steps, step)
+		if err != nil {
+			return report, fmt.Errorf("maintenance step %s: %w", StepRollover, err)
+		}
+	}
+
+	// 2. Force-merge
+	step, err := c.maintenanceForceMerge(ctx, plan.ForceMerge, report.Rollover)
+	report.Steps = append(report.Steps, step)
+	if err != nil {
+		return report, fmt.Errorf("maintenance step %s: %w", StepForceMerge, err)
+	}
+
+	// 3. Retenção
+	if plan.Retention == nil {
+		report.Steps = append(report.Steps, StepReport{Name: StepRetention, Skipped: true})
+	} else {
+		deleted, err := c.CleanupByAge(ctx, plan.Retention.Prefix, plan.Retention.Days)
+		report.Steps = append(report.Steps, StepReport{Name: StepRetention, Indices: deleted, Err: err})
+		if err != nil {
+			return report, fmt.Errorf("maintenance step %s: %w", StepRetention, err)
+		}
+	}
+
+	return report, nil
+}
+
+// maintenanceForceMerge resolve o alvo e executa a etapa de force-merge
+func (c *Client) maintenanceForceMerge(ctx context.Context, spec *ForceMergeStep, rollover *RolloverResult) (StepReport, error) {
+	step := StepReport{Name: StepForceMerge, Skipped: true}
+	if spec == nil || c.DryRun {
+		return step, nil
+	}
+
+	target := spec.Pattern
+	if target == "" {
+		if rollover == nil || !rollover.RolledOver {
+			return step, nil
+		}
+		target = rollover.OldIndex
+	}
+
+	step.Skipped = false
+	step.Indices = []string{target}
+	step.Err = c.ForceMerge(ctx, target, spec.MaxNumSegments, spec.OnlyExpungeDeletes)
+	return step, step.Err
+}
@@ -0,0 +1,111 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// GetIndexMapping retorna o mapping de um índice (o objeto "mappings")
+func (c *Client) GetIndexMapping(ctx context.Context, index string) (map[string]interface{}, error) {
+	var payload map[string]struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+
+	path := fmt.Sprintf("/%s/_mapping", index)
+	if err := c.call(ctx, "GET", path, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to get index mapping: %w", err)
+	}
+
+	if entry, ok := payload[index]; ok {
+		return entry.Mappings, nil
+	}
+	// Um alias resolve para o nome concreto do índice
+	if len(payload) == 1 {
+		for _, entry := range payload {
+			return entry.Mappings, nil
+		}
+	}
+	return nil, fmt.Errorf("index %s not found in mapping response", index)
+}
+
+// UpdateIndexMapping adiciona ou atualiza campos do mapping de um índice,
+// enviando apenas o objeto "properties"
+func (c *Client) UpdateIndexMapping(ctx context.Context, index string, properties map[string]interface{}) error {
+	body := map[string]interface{}{"properties": properties}
+
+	path := fmt.Sprintf("/%s/_mapping", index)
+	if err := c.call(ctx, "PUT", path, body, nil); err != nil {
+		return fmt.Errorf("failed to update index mapping: %w", err)
+	}
+	return nil
+}
+
+// MappingConflict descreve um campo mapeado com tipos diferentes na origem
+// e no destino de uma reindexação
+type MappingConflict struct {
+	// Field é o caminho completo do campo (ex.: "http.response.status_code")
+	Field      string
+	SourceType string
+	DestType   string
+}
+
+// CheckReindexCompatibility compara os mappings de source e dest campo a
+// campo e retorna os conflitos de tipo, que fariam o destino rejeitar
+// documentos. Campos ausentes no destino não são conflitos (serão mapeados
+// dinamicamente, se permitido).
+func (c *Client) CheckReindexCompatibility(ctx context.Context, source, dest string) ([]MappingConflict, error) {
+	sourceMapping, err := c.GetIndexMapping(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	destMapping, err := c.GetIndexMapping(ctx, dest)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceFields := map[string]string{}
+	flattenMapping("", sourceMapping, sourceFields)
+	destFields := map[string]string{}
+	flattenMapping("", destMapping, destFields)
+
+	conflicts := []MappingConflict{}
+	for field, sourceType := range sourceFields {
+		if destType, ok := destFields[field]; ok && destType != sourceType {
+			conflicts = append(conflicts, MappingConflict{Field: field, SourceType: sourceType, DestType: destType})
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Field < conflicts[j].Field
+	})
+
+	return conflicts, nil
+}
+
+// flattenMapping percorre "properties" (e multi-fields em "fields")
+// registrando o tipo de cada campo pelo caminho completo; objetos sem
+// "type" explícito são registrados como "object"
+func flattenMapping(prefix string, mapping map[string]interface{}, fields map[string]string) {
+	for _, key := range []string{"properties", "fields"} {
+		children, _ := mapping[key].(map[string]interface{})
+		for name, child := range children {
+			def, ok := child.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			path := name
+			if prefix != "" {
+				path = prefix + "." + name
+			}
+
+			fieldType, _ := def["type"].(string)
+			if fieldType == "" {
+				fieldType = "object"
+			}
+			fields[path] = fieldType
+
+			flattenMapping(path, def, fields)
+		}
+	}
+}
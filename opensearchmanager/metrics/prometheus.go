@@ -0,0 +1,162 @@
+// Package metrics fornece um Observer que expõe as métricas das requisições
+// do curator no formato texto do Prometheus, sem dependências externas.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager"
+)
+
+// DefaultBuckets são os limites (em segundos) do histograma de latência
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Prometheus acumula contadores por método/status e histogramas de latência
+// por método. O caminho não vira label para evitar alta cardinalidade.
+//
+// Registre-o no cliente e sirva-o em /metrics:
+//
+//	prom := metrics.NewPrometheus("opensearch_curator")
+//	client.Observer = prom
+//	http.Handle("/metrics", prom)
+type Prometheus struct {
+	namespace string
+	buckets   []float64
+
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	durations map[string]*histogram
+}
+
+var _ opensearchmanager.Observer = (*Prometheus)(nil)
+
+type requestKey struct {
+	method string
+	status string
+}
+
+type histogram struct {
+	counts []uint64 // contagem por bucket, não cumulativa
+	sum    float64
+	count  uint64
+}
+
+// NewPrometheus cria o observer com o prefixo informado nos nomes das métricas
+func NewPrometheus(namespace string) *Prometheus {
+	return &Prometheus{
+		namespace: namespace,
+		buckets:   DefaultBuckets,
+		requests:  make(map[requestKey]uint64),
+		durations: make(map[string]*histogram),
+	}
+}
+
+// ObserveRequest implementa opensearchmanager.Observer
+func (p *Prometheus) ObserveRequest(method, path string, status int, dur time.Duration) {
+	key := requestKey{method: method, status: statusLabel(status)}
+	seconds := dur.Seconds()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests[key]++
+
+	h, ok := p.durations[method]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(p.buckets))}
+		p.durations[method] = h
+	}
+	for i, bound := range p.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// statusLabel usa "error" para falhas sem resposta HTTP
+func statusLabel(status int) string {
+	if status == 0 {
+		return "error"
+	}
+	return strconv.Itoa(status)
+}
+
+// ServeHTTP expõe as métricas no formato texto do Prometheus
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	p.WriteTo(w)
+}
+
+// WriteTo escreve as métricas no formato texto do Prometheus
+func (p *Prometheus) WriteTo(w io.Writer) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+
+	requests := p.namespace + "_requests_total"
+	fmt.Fprintf(cw, "# HELP %s Total de requisições ao OpenSearch por método e status.\n", requests)
+	fmt.Fprintf(cw, "# TYPE %s counter\n", requests)
+	keys := make([]requestKey, 0, len(p.requests))
+	for key := range p.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].status < keys[j].status
+	})
+	for _, key := range keys {
+		fmt.Fprintf(cw, "%s{method=%q,status=%q} %d\n", requests, key.method, key.status, p.requests[key])
+	}
+
+	duration := p.namespace + "_request_duration_seconds"
+	fmt.Fprintf(cw, "# HELP %s Latência das requisições ao OpenSearch por método.\n", duration)
+	fmt.Fprintf(cw, "# TYPE %s histogram\n", duration)
+	methods := make([]string, 0, len(p.durations))
+	for method := range p.durations {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		h := p.durations[method]
+		var cumulative uint64
+		for i, bound := range p.buckets {
+			cumulative += h.counts[i]
+			le := strconv.FormatFloat(bound, 'g', -1, 64)
+			fmt.Fprintf(cw, "%s_bucket{method=%q,le=%q} %d\n", duration, method, le, cumulative)
+		}
+		fmt.Fprintf(cw, "%s_bucket{method=%q,le=\"+Inf\"} %d\n", duration, method, h.count)
+		fmt.Fprintf(cw, "%s_sum{method=%q} %g\n", duration, method, h.sum)
+		fmt.Fprintf(cw, "%s_count{method=%q} %d\n", duration, method, h.count)
+	}
+
+	return cw.n, cw.err
+}
+
+// countingWriter registra os bytes escritos e o primeiro erro
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
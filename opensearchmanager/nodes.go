@@ -0,0 +1,116 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// NodeInfo representa informações básicas de um nó do cluster
+type NodeInfo struct {
+	Name           string
+	IP             string
+	Roles          string
+	ClusterManager bool
+	HeapPercent    int
+	RAMPercent     int
+	CPU            int
+	Load1m         float64
+}
+
+// ListNodes retorna os nós do cluster a partir de _cat/nodes
+func (c *Client) ListNodes(ctx context.Context) ([]NodeInfo, error) {
+	var rows []struct {
+		Name           string `json:"name"`
+		IP             string `json:"ip"`
+		Role           string `json:"node.role"`
+		Master         string `json:"master"`
+		ClusterManager string `json:"cluster_manager"`
+		HeapPercent    string `json:"heap.percent"`
+		RAMPercent     string `json:"ram.percent"`
+		CPU            string `json:"cpu"`
+		Load1m         string `json:"load_1m"`
+	}
+
+	if err := c.call(ctx, "GET", "/_cat/nodes?format=json", nil, &rows); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var result []NodeInfo
+	for _, row := range rows {
+		load, _ := strconv.ParseFloat(row.Load1m, 64)
+		result = append(result, NodeInfo{
+			Name:  row.Name,
+			IP:    row.IP,
+			Roles: row.Role,
+			// Versões novas usam "cluster_manager"; antigas, "master"
+			ClusterManager: row.ClusterManager == "*" || row.Master == "*",
+			HeapPercent:    atoiOrZero(row.HeapPercent),
+			RAMPercent:     atoiOrZero(row.RAMPercent),
+			CPU:            atoiOrZero(row.CPU),
+			Load1m:         load,
+		})
+	}
+
+	return result, nil
+}
+
+// DiskInfo representa o uso de disco de um nó
+type DiskInfo struct {
+	Node         string
+	Host         string
+	IP           string
+	Shards       int
+	IndicesBytes int64
+	UsedBytes    int64
+	AvailBytes   int64
+	TotalBytes   int64
+	UsedPercent  int
+}
+
+// DiskUsage retorna o uso de disco por nó a partir de _cat/allocation
+func (c *Client) DiskUsage(ctx context.Context) ([]DiskInfo, error) {
+	var rows []struct {
+		Node        string `json:"node"`
+		Host        string `json:"host"`
+		IP          string `json:"ip"`
+		Shards      string `json:"shards"`
+		DiskIndices string `json:"disk.indices"`
+		DiskUsed    string `json:"disk.used"`
+		DiskAvail   string `json:"disk.avail"`
+		DiskTotal   string `json:"disk.total"`
+		DiskPercent string `json:"disk.percent"`
+	}
+
+	if err := c.call(ctx, "GET", "/_cat/allocation?format=json", nil, &rows); err != nil {
+		return nil, fmt.Errorf("failed to get disk usage: %w", err)
+	}
+
+	var result []DiskInfo
+	for _, row := range rows {
+		// A linha UNASSIGNED não tem valores de disco; os campos ficam zerados
+		indices, _ := parseByteSize(row.DiskIndices)
+		used, _ := parseByteSize(row.DiskUsed)
+		avail, _ := parseByteSize(row.DiskAvail)
+		total, _ := parseByteSize(row.DiskTotal)
+		result = append(result, DiskInfo{
+			Node:         row.Node,
+			Host:         row.Host,
+			IP:           row.IP,
+			Shards:       atoiOrZero(row.Shards),
+			IndicesBytes: indices,
+			UsedBytes:    used,
+			AvailBytes:   avail,
+			TotalBytes:   total,
+			UsedPercent:  atoiOrZero(row.DiskPercent),
+		})
+	}
+
+	return result, nil
+}
+
+// atoiOrZero converte colunas numéricas do _cat, retornando zero se vazias
+func atoiOrZero(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,138 @@
+// Package opensearchtest fornece um servidor OpenSearch falso, baseado em
+// httptest, para testar código que usa o pacote opensearchmanager sem um
+// cluster real.
+//
+//	srv := opensearchtest.NewServer()
+//	defer srv.Close()
+//	srv.Handle("GET", "/_cat/indices", 200, `[{"index":"logs-1","status":"open"}]`)
+//
+//	client := srv.Client()
+//	indices, err := client.ListIndices(ctx)
+//	req := srv.Requests()[0] // método, caminho, query e corpo enviados
+package opensearchtest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager"
+)
+
+// Request é uma requisição recebida pelo servidor falso
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Response é a resposta configurada para um método e caminho
+type Response struct {
+	StatusCode int
+	Body       string
+	Header     http.Header
+}
+
+// Server é um OpenSearch falso que responde conforme as rotas registradas e
+// grava todas as requisições recebidas. Rotas não registradas retornam 404
+// no formato de erro do OpenSearch.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	routes   map[string][]Response
+	requests []Request
+}
+
+// NewServer inicia o servidor falso
+func NewServer() *Server {
+	s := &Server{routes: make(map[string][]Response)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Client retorna um cliente apontando para o servidor, sem retentativas
+func (s *Server) Client() *opensearchmanager.Client {
+	return opensearchmanager.NewClient(s.URL, "admin", "admin")
+}
+
+// Handle registra a resposta para o método e caminho (sem query string).
+// Várias chamadas para a mesma rota enfileiram respostas, consumidas em
+// ordem; a última se repete para as requisições seguintes.
+func (s *Server) Handle(method, path string, status int, body string) {
+	s.HandleResponse(method, path, Response{StatusCode: status, Body: body})
+}
+
+// HandleResponse registra uma resposta com cabeçalhos customizados
+func (s *Server) HandleResponse(method, path string, resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := routeKey(method, path)
+	s.routes[key] = append(s.routes[key], resp)
+}
+
+// Requests retorna uma cópia das requisições recebidas até o momento
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Reset remove as rotas e as requisições gravadas
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes = make(map[string][]Response)
+	s.requests = nil
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	key := routeKey(r.Method, r.URL.Path)
+	queue, ok := s.routes[key]
+	var resp Response
+	if ok {
+		resp = queue[0]
+		if len(queue) > 1 {
+			s.routes[key] = queue[1:]
+		}
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"error":{"type":"no_route","reason":"no route for %s"},"status":404}`, key)
+		return
+	}
+
+	for name, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.WriteString(w, resp.Body)
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
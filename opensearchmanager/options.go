@@ -0,0 +1,62 @@
+package opensearchmanager
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RequestOption acrescenta parâmetros de query à requisição principal de uma
+// operação (ex.: o DELETE de DeleteIndices), sem afetar as consultas
+// auxiliares feitas para resolver os índices
+type RequestOption func(params url.Values)
+
+// WithParam define um parâmetro de query arbitrário
+func WithParam(key, value string) RequestOption {
+	return func(params url.Values) {
+		params.Set(key, value)
+	}
+}
+
+// WithIgnoreUnavailable ignora índices ausentes ou fechados em vez de falhar
+func WithIgnoreUnavailable(ignore bool) RequestOption {
+	return WithParam("ignore_unavailable", strconv.FormatBool(ignore))
+}
+
+// WithAllowNoIndices define se curingas sem correspondência são aceitos
+func WithAllowNoIndices(allow bool) RequestOption {
+	return WithParam("allow_no_indices", strconv.FormatBool(allow))
+}
+
+// WithExpandWildcards define a quais índices os curingas se expandem
+// (open, closed, hidden, none, all)
+func WithExpandWildcards(states ...string) RequestOption {
+	return WithParam("expand_wildcards", strings.Join(states, ","))
+}
+
+// withRequestOptions aplica as opções ao caminho, preservando a query existente
+func withRequestOptions(path string, opts []RequestOption) string {
+	if len(opts) == 0 {
+		return path
+	}
+
+	base, query, _ := strings.Cut(path, "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		params = url.Values{}
+	}
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	if len(params) == 0 {
+		return base
+	}
+	return base + "?" + params.Encode()
+}
+
+// WithRequestsPerSecond limita a vazão de operações em lote no servidor
+// (_update_by_query, _delete_by_query)
+func WithRequestsPerSecond(rps float64) RequestOption {
+	return WithParam("requests_per_second", strconv.FormatFloat(rps, 'f', -1, 64))
+}
@@ -0,0 +1,55 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// CreatePIT abre um point-in-time sobre o índice, mantido por keepAlive
+// (ex.: "5m"), e retorna seu ID. Libere-o com DeletePIT ao terminar.
+func (c *Client) CreatePIT(ctx context.Context, index, keepAlive string) (string, error) {
+	var result struct {
+		PitID string `json:"pit_id"`
+	}
+
+	path := fmt.Sprintf("/%s/_search/point_in_time?keep_alive=%s", index, url.QueryEscape(keepAlive))
+	if err := c.call(ctx, "POST", path, nil, &result); err != nil {
+		return "", fmt.Errorf("failed to create point in time: %w", err)
+	}
+	if result.PitID == "" {
+		return "", fmt.Errorf("point in time response did not include an id")
+	}
+
+	return result.PitID, nil
+}
+
+// DeletePIT libera um point-in-time no servidor
+func (c *Client) DeletePIT(ctx context.Context, pitID string) error {
+	body := map[string]interface{}{"pit_id": []string{pitID}}
+	if err := c.call(ctx, "DELETE", "/_search/point_in_time", body, nil); err != nil {
+		return fmt.Errorf("failed to delete point in time: %w", err)
+	}
+	return nil
+}
+
+// SearchPIT executa uma busca sobre o point-in-time, renovando-o por
+// keepAlive. Para paginar, inclua "sort" no body e repasse em "search_after"
+// os valores de sort do último hit da página anterior, usando o PitID
+// retornado na resposta.
+func (c *Client) SearchPIT(ctx context.Context, pitID, keepAlive string, body map[string]interface{}) (*SearchResponse, error) {
+	// A busca com PIT não aceita índice no caminho
+	request := mergeSettings(body, map[string]interface{}{
+		"pit": map[string]interface{}{
+			"id":         pitID,
+			"keep_alive": keepAlive,
+		},
+	})
+
+	var payload searchPayload
+	if err := c.call(ctx, "POST", "/_search", request, &payload); err != nil {
+		return nil, fmt.Errorf("failed to search point in time: %w", err)
+	}
+
+	return payload.response(), nil
+}
@@ -0,0 +1,152 @@
+package opensearchmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Policy descreve de forma declarativa a manutenção de um ou mais conjuntos
+// de índices, podendo ser carregada de um arquivo (ver LoadPolicyFile)
+type Policy struct {
+	Indices []IndexPolicy `json:"indices" yaml:"indices"`
+}
+
+// IndexPolicy define rollover, force-merge e retenção para os índices com
+// o prefixo informado; etapas omitidas não são executadas
+type IndexPolicy struct {
+	Prefix string `json:"prefix" yaml:"prefix"`
+	// RetentionDays remove índices mais antigos que N dias; zero desativa
+	RetentionDays int               `json:"retention_days,omitempty" yaml:"retention_days,omitempty"`
+	Rollover      *RolloverPolicy   `json:"rollover,omitempty" yaml:"rollover,omitempty"`
+	ForceMerge    *ForceMergePolicy `json:"force_merge,omitempty" yaml:"force_merge,omitempty"`
+}
+
+// RolloverPolicy define o alias de escrita e as condições de rollover
+type RolloverPolicy struct {
+	Alias              string `json:"alias" yaml:"alias"`
+	RolloverConditions `yaml:",inline"`
+}
+
+// ForceMergePolicy define o force-merge do índice anterior após o rollover
+type ForceMergePolicy struct {
+	MaxNumSegments     int  `json:"max_num_segments,omitempty" yaml:"max_num_segments,omitempty"`
+	OnlyExpungeDeletes bool `json:"only_expunge_deletes,omitempty" yaml:"only_expunge_deletes,omitempty"`
+}
+
+// PolicyReport reúne o relatório de manutenção de cada IndexPolicy
+type PolicyReport struct {
+	Results []PolicyResult
+}
+
+// PolicyResult é o resultado da aplicação de uma IndexPolicy
+type PolicyResult struct {
+	Prefix      string
+	Maintenance *MaintenanceReport
+	Err         error
+}
+
+// LoadPolicy lê uma Policy em JSON e a valida
+func LoadPolicy(r io.Reader) (*Policy, error) {
+	var p Policy
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to decode policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// LoadPolicyFile lê uma Policy em JSON de um arquivo
+func LoadPolicyFile(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open policy file: %w", err)
+	}
+	defer f.Close()
+	return LoadPolicy(f)
+}
+
+// Validate verifica a política antes de qualquer chamada ao cluster
+func (p Policy) Validate() error {
+	if len(p.Indices) == 0 {
+		return fmt.Errorf("policy has no index rules")
+	}
+
+	var errs []error
+	for i, ip := range p.Indices {
+		if err := ip.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("indices[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (ip IndexPolicy) validate() error {
+	if ip.Prefix == "" {
+		return fmt.Errorf("prefix is required")
+	}
+	if ip.RetentionDays < 0 {
+		return fmt.Errorf("retention_days must be positive, got %d", ip.RetentionDays)
+	}
+	if ip.Rollover != nil {
+		if ip.Rollover.Alias == "" {
+			return fmt.Errorf("rollover alias is required")
+		}
+		if len(ip.Rollover.Map()) == 0 {
+			return fmt.Errorf("rollover requires at least one condition")
+		}
+	}
+	if ip.ForceMerge != nil && ip.ForceMerge.MaxNumSegments < 0 {
+		return fmt.Errorf("max_num_segments must not be negative, got %d", ip.ForceMerge.MaxNumSegments)
+	}
+	if ip.ForceMerge != nil && ip.Rollover == nil {
+		return fmt.Errorf("force_merge requires rollover to select the previous index")
+	}
+	return nil
+}
+
+// plan converte a IndexPolicy em um MaintenancePlan
+func (ip IndexPolicy) plan() MaintenancePlan {
+	var plan MaintenancePlan
+	if ip.Rollover != nil {
+		plan.Rollover = &RolloverStep{Alias: ip.Rollover.Alias, Conditions: ip.Rollover.Map()}
+	}
+	if ip.ForceMerge != nil {
+		plan.ForceMerge = &ForceMergeStep{
+			MaxNumSegments:     ip.ForceMerge.MaxNumSegments,
+			OnlyExpungeDeletes: ip.ForceMerge.OnlyExpungeDeletes,
+		}
+	}
+	if ip.RetentionDays > 0 {
+		plan.Retention = &RetentionStep{Prefix: ip.Prefix, Days: ip.RetentionDays}
+	}
+	return plan
+}
+
+// ApplyPolicy valida a política e executa RunMaintenance para cada
+// IndexPolicy. Uma falha em um prefixo não impede os demais; os erros são
+// combinados no retorno e registrados em cada PolicyResult.
+func (c *Client) ApplyPolicy(ctx context.Context, p Policy) (*PolicyReport, error) {
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+
+	report := &PolicyReport{}
+	var errs []error
+	for _, ip := range p.Indices {
+		maintenance, err := c.RunMaintenance(ctx, ip.plan())
+		report.Results = append(report.Results, PolicyResult{Prefix: ip.Prefix, Maintenance: maintenance, Err: err})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("policy %s: %w", ip.Prefix, err))
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
@@ -0,0 +1,58 @@
+package opensearchmanager
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultProtectedPatterns cobre os índices internos do Dashboards e dos
+// plugins do OpenSearch. Não é aplicado automaticamente; para usá-lo:
+//
+//	client.ProtectedPatterns = opensearchmanager.DefaultProtectedPatterns
+var DefaultProtectedPatterns = []string{".kibana*", ".opendistro*"}
+
+// isProtected indica se o índice corresponde a algum ProtectedPatterns
+func (c *Client) isProtected(name string) bool {
+	for _, pattern := range c.ProtectedPatterns {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// skipDestructive indica se o índice deve ficar fora de exclusões e
+// fechamentos em lote
+func (c *Client) skipDestructive(idx IndexInfo) bool {
+	return c.isExcluded(idx) || c.isProtected(idx.Name)
+}
+
+// unprotected restringe o matcher a índices não protegidos
+func (c *Client) unprotected(match indexMatcher) indexMatcher {
+	return func(idx IndexInfo) bool {
+		return !c.isProtected(idx.Name) && match(idx)
+	}
+}
+
+// checkProtected retorna ErrProtectedIndex quando o padrão (ou lista separada
+// por vírgulas) nomeia explicitamente, sem curingas, um índice protegido
+func (c *Client) checkProtected(pattern string) error {
+	var names []string
+	for _, name := range strings.Split(pattern, ",") {
+		if !strings.ContainsAny(name, "*?[") {
+			names = append(names, name)
+		}
+	}
+	return c.checkProtectedNames(names)
+}
+
+// checkProtectedNames retorna ErrProtectedIndex se algum nome for protegido
+func (c *Client) checkProtectedNames(names []string) error {
+	for _, name := range names {
+		if c.isProtected(name) {
+			return fmt.Errorf("%w: %s", ErrProtectedIndex, name)
+		}
+	}
+	return nil
+}
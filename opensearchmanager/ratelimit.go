@@ -0,0 +1,59 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter limita a vazão de requisições ao cluster. É satisfeita por
+// *rate.Limiter (golang.org/x/time/rate) e por NewRateLimiter.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// NewRateLimiter cria um limitador simples que espaça as requisições para no
+// máximo requestsPerSecond; valores <= 0 retornam nil (sem limite)
+func NewRateLimiter(requestsPerSecond float64) RateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &intervalLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+}
+
+// intervalLimiter libera uma requisição a cada interval
+type intervalLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// Wait reserva o próximo horário livre e aguarda até ele ou o cancelamento
+// do contexto; a reserva não é devolvida em caso de cancelamento
+func (l *intervalLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	return sleepContext(ctx, wait)
+}
+
+// waitRate aguarda a liberação do RateLimiter, se configurado
+func (c *Client) waitRate(ctx context.Context) error {
+	if c.RateLimiter == nil {
+		return nil
+	}
+	if err := c.RateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limiter: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ShardRecovery representa o progresso de recuperação de um shard
+type ShardRecovery struct {
+	Index   string
+	Shard   int
+	Primary bool
+	// Type indica a origem da recuperação (EMPTY_STORE, PEER, SNAPSHOT, ...)
+	Type string
+	// Stage é a etapa atual (INIT, INDEX, TRANSLOG, FINALIZE, DONE)
+	Stage          string
+	SourceNode     string
+	TargetNode     string
+	TotalBytes     int64
+	RecoveredBytes int64
+	// Percent é o percentual de bytes recuperados (0 a 100)
+	Percent float64
+}
+
+// RecoveryOptions define parâmetros opcionais de RecoveryStatusWithOptions
+type RecoveryOptions struct {
+	// ActiveOnly omite shards cuja recuperação já terminou
+	ActiveOnly bool
+}
+
+// RecoveryStatus retorna o progresso de recuperação de cada shard do índice,
+// útil para acompanhar restores, shrinks e realocações
+func (c *Client) RecoveryStatus(ctx context.Context, index string) ([]ShardRecovery, error) {
+	return c.RecoveryStatusWithOptions(ctx, index, RecoveryOptions{})
+}
+
+// RecoveryStatusWithOptions é RecoveryStatus com filtro de shards ativos
+func (c *Client) RecoveryStatusWithOptions(ctx context.Context, index string, opts RecoveryOptions) ([]ShardRecovery, error) {
+	type node struct {
+		Name string `json:"name"`
+	}
+	var payload map[string]struct {
+		Shards []struct {
+			ID      int    `json:"id"`
+			Type    string `json:"type"`
+			Stage   string `json:"stage"`
+			Primary bool   `json:"primary"`
+			Source  node   `json:"source"`
+			Target  node   `json:"target"`
+			Index   struct {
+				Size struct {
+					TotalInBytes     int64  `json:"total_in_bytes"`
+					RecoveredInBytes int64  `json:"recovered_in_bytes"`
+					Percent          string `json:"percent"`
+				} `json:"size"`
+			} `json:"index"`
+		} `json:"shards"`
+	}
+
+	path := fmt.Sprintf("/%s/_recovery", index)
+	if opts.ActiveOnly {
+		path += "?active_only=true"
+	}
+	if err := c.call(ctx, "GET", path, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to get recovery status: %w", err)
+	}
+
+	result := []ShardRecovery{}
+	for name, entry := range payload {
+		for _, shard := range entry.Shards {
+			// percent vem como "42.5%"
+			percent, _ := strconv.ParseFloat(strings.TrimSuffix(shard.Index.Size.Percent, "%"), 64)
+			result = append(result, ShardRecovery{
+				Index:          name,
+				Shard:          shard.ID,
+				Primary:        shard.Primary,
+				Type:           shard.Type,
+				Stage:          shard.Stage,
+				SourceNode:     shard.Source.Name,
+				TargetNode:     shard.Target.Name,
+				TotalBytes:     shard.Index.Size.TotalInBytes,
+				RecoveredBytes: shard.Index.Size.RecoveredInBytes,
+				Percent:        percent,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Index != result[j].Index {
+			return result[i].Index < result[j].Index
+		}
+		if result[i].Shard != result[j].Shard {
+			return result[i].Shard < result[j].Shard
+		}
+		return result[i].Primary && !result[j].Primary
+	})
+
+	return result, nil
+}
@@ -0,0 +1,235 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager"
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+func TestReindexWithOptions(t *testing.T) {
+	script := map[string]interface{}{"source": "ctx._source.remove('tmp')", "lang": "painless"}
+	query := map[string]interface{}{"term": map[string]interface{}{"level": "error"}}
+
+	tests := []struct {
+		name      string
+		opts      opensearchmanager.ReindexOptions
+		wantQuery map[string]string
+		wantBody  string
+	}{
+		{
+			name:      "defaults",
+			wantQuery: map[string]string{},
+			wantBody:  `{"dest":{"index":"logs-new"},"source":{"index":"logs-1"}}`,
+		},
+		{
+			name:      "slices",
+			opts:      opensearchmanager.ReindexOptions{Slices: 4},
+			wantQuery: map[string]string{"slices": "4"},
+			wantBody:  `{"dest":{"index":"logs-new"},"source":{"index":"logs-1"}}`,
+		},
+		{
+			name:      "slices auto",
+			opts:      opensearchmanager.ReindexOptions{Slices: opensearchmanager.SlicesAuto},
+			wantQuery: map[string]string{"slices": "auto"},
+			wantBody:  `{"dest":{"index":"logs-new"},"source":{"index":"logs-1"}}`,
+		},
+		{
+			name:      "requests per second",
+			opts:      opensearchmanager.ReindexOptions{RequestsPerSecond: 500.5},
+			wantQuery: map[string]string{"requests_per_second": "500.5"},
+			wantBody:  `{"dest":{"index":"logs-new"},"source":{"index":"logs-1"}}`,
+		},
+		{
+			name:      "query and script",
+			opts:      opensearchmanager.ReindexOptions{Query: query, Script: script},
+			wantQuery: map[string]string{},
+			wantBody: `{"dest":{"index":"logs-new"},"script":{"lang":"painless","source":"ctx._source.remove('tmp')"},` +
+				`"source":{"index":"logs-1","query":{"term":{"level":"error"}}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle("POST", "/_reindex", 200, `{"took":10,"total":3,"created":3,"failures":[]}`)
+
+			if err := srv.Client().ReindexWithOptions(context.Background(), "logs-1", "logs-new", tt.opts); err != nil {
+				t.Fatal(err)
+			}
+
+			reqs := srv.Requests()
+			if len(reqs) != 1 {
+				t.Fatalf("got %d requests, want 1", len(reqs))
+			}
+			got := map[string]string{}
+			for key := range reqs[0].Query {
+				got[key] = reqs[0].Query.Get(key)
+			}
+			if !reflect.DeepEqual(got, tt.wantQuery) {
+				t.Errorf("query %v, want %v", got, tt.wantQuery)
+			}
+			assertJSON(t, reqs[0].Body, tt.wantBody)
+		})
+	}
+}
+
+func TestReindexAsync(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("POST", "/_reindex", 200, `{"task":"node-1:42"}`)
+
+	opts := opensearchmanager.ReindexOptions{Slices: 2, DisableRefresh: true}
+	task, err := srv.Client().ReindexAsyncWithOptions(context.Background(), "logs-1", "logs-new", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task != "node-1:42" {
+		t.Errorf("task = %q, want node-1:42", task)
+	}
+
+	// DisableRefresh não se aplica ao modo assíncrono
+	reqs := srv.Requests()
+	if len(reqs) != 1 {
+		t.Fatalf("got %d requests, want 1", len(reqs))
+	}
+	if q := reqs[0].Query; q.Get("wait_for_completion") != "false" || q.Get("slices") != "2" {
+		t.Errorf("unexpected query: %v", q)
+	}
+}
+
+func TestReindexDisableRefreshRestoresOnFailure(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/logs-new/_settings", 200, `{"logs-new":{"settings":{"index.refresh_interval":"5s"}}}`)
+	srv.Handle("PUT", "/logs-new/_settings", 200, `{"acknowledged":true}`)
+	srv.Handle("POST", "/_reindex", 500, `{"error":{"type":"exception","reason":"boom"},"status":500}`)
+
+	opts := opensearchmanager.ReindexOptions{DisableRefresh: true}
+	if err := srv.Client().ReindexWithOptions(context.Background(), "logs-1", "logs-new", opts); err == nil {
+		t.Fatal("expected reindex error")
+	}
+
+	want := []string{
+		"PUT /logs-new/_settings",
+		"POST /_reindex",
+		"PUT /logs-new/_settings",
+	}
+	if got := writes(srv); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests %v, want %v", got, want)
+	}
+	var bodies []string
+	for _, req := range srv.Requests() {
+		if req.Method == "PUT" {
+			bodies = append(bodies, string(req.Body))
+		}
+	}
+	if len(bodies) != 2 || !strings.Contains(bodies[0], `"-1"`) || !strings.Contains(bodies[1], `"5s"`) {
+		t.Errorf("unexpected settings bodies: %v", bodies)
+	}
+}
+
+func TestReindexDisableRefreshMissingDest(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/logs-new/_settings", 404, `{"error":{"type":"index_not_found_exception","reason":"no such index [logs-new]"},"status":404}`)
+	srv.Handle("POST", "/_reindex", 200, `{"took":10,"total":3,"created":3,"failures":[]}`)
+
+	opts := opensearchmanager.ReindexOptions{DisableRefresh: true}
+	if err := srv.Client().ReindexWithOptions(context.Background(), "logs-1", "logs-new", opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := writes(srv), []string{"POST /_reindex"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("requests %v, want %v", got, want)
+	}
+}
+
+func TestReindexDisableRefreshHiddenDest(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/.archive/_settings", 200, `{".archive":{"settings":{"index.refresh_interval":"5s"}}}`)
+	srv.Handle("PUT", "/.archive/_settings", 200, `{"acknowledged":true}`)
+	srv.Handle("POST", "/_reindex", 200, `{"took":10,"total":3,"created":3,"failures":[]}`)
+	srv.Handle("POST", "/.archive/_refresh", 200, `{"_shards":{"total":1,"successful":1,"failed":0}}`)
+
+	opts := opensearchmanager.ReindexOptions{DisableRefresh: true}
+	if err := srv.Client().ReindexWithOptions(context.Background(), "logs-1", ".archive", opts); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"PUT /.archive/_settings",
+		"POST /_reindex",
+		"PUT /.archive/_settings",
+		"POST /.archive/_refresh",
+	}
+	if got := writes(srv); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests %v, want %v", got, want)
+	}
+	for _, req := range srv.Requests() {
+		if req.Path == "/_cat/indices" {
+			t.Error("refresh must target dest by name, without listing the cluster")
+		}
+	}
+}
+
+func TestRolloverWithOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      opensearchmanager.RolloverOptions
+		wantPath  string
+		wantQuery string
+	}{
+		{"defaults", opensearchmanager.RolloverOptions{}, "/logs/_rollover", ""},
+		{"new index", opensearchmanager.RolloverOptions{NewIndex: "logs-000010"}, "/logs/_rollover/logs-000010", ""},
+		{"dry run", opensearchmanager.RolloverOptions{DryRun: true}, "/logs/_rollover", "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle("POST", tt.wantPath, 200, `{"acknowledged":true,"shards_acknowledged":true,`+
+				`"old_index":"logs-000001","new_index":"logs-000002","rolled_over":true,"dry_run":false,`+
+				`"conditions":{"[max_docs: 1000]":true,"[max_age: 7d]":false}}`)
+
+			conditions := opensearchmanager.RolloverConditions{MaxAge: "7d", MaxDocs: 1000}
+			result, err := srv.Client().RolloverWithConditions(context.Background(), "logs", conditions, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.RolledOver || result.OldIndex != "logs-000001" || result.NewIndex != "logs-000002" {
+				t.Errorf("unexpected result: %+v", result)
+			}
+			if !result.Conditions["[max_docs: 1000]"] || result.Conditions["[max_age: 7d]"] {
+				t.Errorf("unexpected conditions: %v", result.Conditions)
+			}
+
+			req := srv.Requests()[0]
+			if got := req.Query.Get("dry_run"); got != tt.wantQuery {
+				t.Errorf("dry_run = %q, want %q", got, tt.wantQuery)
+			}
+			assertJSON(t, req.Body, `{"conditions":{"max_age":"7d","max_docs":1000}}`)
+		})
+	}
+}
+
+// assertJSON compara dois documentos JSON ignorando a ordem das chaves
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var g, w interface{}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("invalid JSON %q: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("invalid expected JSON %q: %v", want, err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("body %s, want %s", got, want)
+	}
+}
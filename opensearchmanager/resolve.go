@@ -0,0 +1,53 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ResolveResult descreve no que uma expressão de índices se resolve,
+// segundo a semântica do próprio OpenSearch (curingas, exclusões com "-",
+// date math)
+type ResolveResult struct {
+	Indices     []ResolvedIndex      `json:"indices"`
+	Aliases     []ResolvedAlias      `json:"aliases"`
+	DataStreams []ResolvedDataStream `json:"data_streams"`
+}
+
+// ResolvedIndex é um índice concreto encontrado pela expressão
+type ResolvedIndex struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+	// Attributes traz flags como "open", "closed", "hidden" e "frozen"
+	Attributes []string `json:"attributes"`
+	// DataStream é o data stream ao qual o índice pertence, se houver
+	DataStream string `json:"data_stream"`
+}
+
+// ResolvedAlias é um alias encontrado pela expressão
+type ResolvedAlias struct {
+	Name    string   `json:"name"`
+	Indices []string `json:"indices"`
+}
+
+// ResolvedDataStream é um data stream encontrado pela expressão
+type ResolvedDataStream struct {
+	Name           string   `json:"name"`
+	BackingIndices []string `json:"backing_indices"`
+	TimestampField string   `json:"timestamp_field"`
+}
+
+// ResolveIndexExpression resolve a expressão no servidor via
+// /_resolve/index/{expr}, revelando também se um nome é alias ou data stream
+func (c *Client) ResolveIndexExpression(ctx context.Context, expr string) (*ResolveResult, error) {
+	var result ResolveResult
+
+	// Date math (ex.: <logs-{now/d}>) precisa ser escapado no caminho
+	path := fmt.Sprintf("/_resolve/index/%s", url.PathEscape(expr))
+	if err := c.call(ctx, "GET", path, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to resolve index expression: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,137 @@
+package opensearchmanager
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controla novas tentativas em falhas transitórias: respostas com
+// status em RetryableStatusCodes e, só em métodos idempotentes, erros de conexão
+type RetryPolicy struct {
+	// MaxAttempts é o total de tentativas, incluindo a primeira
+	MaxAttempts int
+	// BaseDelay é o atraso inicial, dobrado a cada tentativa
+	BaseDelay time.Duration
+	// MaxDelay limita o atraso calculado pelo backoff
+	MaxDelay time.Duration
+	// RetryableStatusCodes lista os status que disparam nova tentativa
+	// (padrão: 429, 502, 503, 504)
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy retorna uma política com valores razoáveis para produção
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            500 * time.Millisecond,
+		MaxDelay:             10 * time.Second,
+		RetryableStatusCodes: defaultRetryableStatusCodes(),
+	}
+}
+
+func defaultRetryableStatusCodes() []int {
+	return []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+}
+
+// attempts retorna o número máximo de tentativas (no mínimo 1)
+func (p *RetryPolicy) attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// shouldRetry decide se a resposta/erro justifica uma nova tentativa. Erros
+// de transporte só são repetidos em métodos idempotentes: um POST (_reindex,
+// _bulk, _shrink) pode ter chegado ao servidor antes da conexão cair.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, method string, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return idempotent(method) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	codes := p.RetryableStatusCodes
+	if len(codes) == 0 {
+		codes = defaultRetryableStatusCodes()
+	}
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// idempotent indica se reenviar a requisição não altera o resultado
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// delay calcula a espera antes da próxima tentativa, priorizando Retry-After
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+
+	base := p.BaseDelay
+	if base <= 0 {
+		base = 100 * time.Millisecond
+	}
+	d := base << (attempt - 1)
+	if p.MaxDelay > 0 && (d > p.MaxDelay || d <= 0) {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// parseRetryAfter interpreta o cabeçalho Retry-After em segundos ou como data HTTP
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// fitsDeadline verifica se ainda há tempo no contexto para esperar d
+func fitsDeadline(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > d
+}
+
+// sleepContext aguarda d ou até o cancelamento do contexto
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,135 @@
+package opensearchmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// scrollKeepAlive é o tempo que o contexto de scroll é mantido entre páginas
+const scrollKeepAlive = "1m"
+
+// ScrollIterator percorre os documentos de um índice página a página
+type ScrollIterator struct {
+	client   *Client
+	ctx      context.Context
+	scrollID string
+	pending  []json.RawMessage
+	started  bool
+	done     bool
+}
+
+// Scroll abre um contexto de scroll sobre o índice. Chame Next até receber
+// io.EOF e sempre chame Close para liberar o contexto no servidor, mesmo
+// que a iteração termine antes.
+func (c *Client) Scroll(ctx context.Context, index string, query map[string]interface{}, pageSize int) (*ScrollIterator, error) {
+	if pageSize <= 0 {
+		pageSize = 1000
+	}
+
+	body := map[string]interface{}{}
+	if len(query) > 0 {
+		body["query"] = query
+	}
+
+	params := url.Values{}
+	params.Set("scroll", scrollKeepAlive)
+	params.Set("size", strconv.Itoa(pageSize))
+
+	var page scrollPage
+	path := fmt.Sprintf("/%s/_search?%s", index, params.Encode())
+	if err := c.call(ctx, "POST", path, body, &page); err != nil {
+		return nil, fmt.Errorf("failed to open scroll: %w", err)
+	}
+
+	return &ScrollIterator{
+		client:   c,
+		ctx:      ctx,
+		scrollID: page.ScrollID,
+		pending:  page.sources(),
+	}, nil
+}
+
+// Next retorna a próxima página de documentos (_source). Ao final retorna
+// io.EOF e libera o contexto de scroll automaticamente.
+func (it *ScrollIterator) Next() ([]json.RawMessage, error) {
+	if it.done {
+		return nil, io.EOF
+	}
+
+	if !it.started {
+		it.started = true
+		if len(it.pending) > 0 {
+			return it.pending, nil
+		}
+		return nil, it.finish()
+	}
+
+	body := map[string]interface{}{
+		"scroll":    scrollKeepAlive,
+		"scroll_id": it.scrollID,
+	}
+
+	var page scrollPage
+	if err := it.client.call(it.ctx, "POST", "/_search/scroll", body, &page); err != nil {
+		return nil, fmt.Errorf("failed to fetch scroll page: %w", err)
+	}
+	if page.ScrollID != "" {
+		it.scrollID = page.ScrollID
+	}
+
+	docs := page.sources()
+	if len(docs) == 0 {
+		return nil, it.finish()
+	}
+	return docs, nil
+}
+
+// Close libera o contexto de scroll no servidor; pode ser chamado mais de uma vez
+func (it *ScrollIterator) Close() error {
+	it.done = true
+	if it.scrollID == "" {
+		return nil
+	}
+
+	body := map[string]interface{}{
+		"scroll_id": []string{it.scrollID},
+	}
+	it.scrollID = ""
+
+	// O scroll deve ser liberado mesmo que o contexto original já tenha sido cancelado
+	ctx := context.WithoutCancel(it.ctx)
+	if err := it.client.call(ctx, "DELETE", "/_search/scroll", body, nil); err != nil && !IsNotFound(err) {
+		return fmt.Errorf("failed to clear scroll: %w", err)
+	}
+	return nil
+}
+
+// finish encerra a iteração, liberando o scroll, e retorna io.EOF
+func (it *ScrollIterator) finish() error {
+	if err := it.Close(); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+// scrollPage representa uma página de resposta de busca com scroll
+type scrollPage struct {
+	ScrollID string `json:"_scroll_id"`
+	Hits     struct {
+		Hits []struct {
+			Source json.RawMessage `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func (p scrollPage) sources() []json.RawMessage {
+	docs := make([]json.RawMessage, 0, len(p.Hits.Hits))
+	for _, hit := range p.Hits.Hits {
+		docs = append(docs, hit.Source)
+	}
+	return docs
+}
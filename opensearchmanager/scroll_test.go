@@ -0,0 +1,86 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+func TestScroll(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("POST", "/logs-1/_search", 200, `{"_scroll_id":"s1","hits":{"hits":[{"_source":{"n":1}},{"_source":{"n":2}}]}}`)
+	srv.Handle("POST", "/_search/scroll", 200, `{"_scroll_id":"s2","hits":{"hits":[{"_source":{"n":3}}]}}`)
+	srv.Handle("POST", "/_search/scroll", 200, `{"_scroll_id":"s2","hits":{"hits":[]}}`)
+	srv.Handle("DELETE", "/_search/scroll", 200, `{"succeeded":true,"num_freed":1}`)
+
+	it, err := srv.Client().Scroll(context.Background(), "logs-1", nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	var docs int
+	for {
+		page, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs += len(page)
+	}
+	if docs != 3 {
+		t.Errorf("got %d docs, want 3", docs)
+	}
+
+	// O fim da iteração libera o último scroll_id; Close depois disso não repete
+	if err := it.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"POST /logs-1/_search", "POST /_search/scroll", "POST /_search/scroll", "DELETE /_search/scroll"}
+	if got := writes(srv); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests %v, want %v", got, want)
+	}
+	assertJSON(t, srv.Requests()[3].Body, `{"scroll_id":["s2"]}`)
+}
+
+func TestScrollCloseEarly(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("POST", "/logs-1/_search", 200, `{"_scroll_id":"s1","hits":{"hits":[{"_source":{"n":1}}]}}`)
+	// Um scroll já expirado no servidor não é tratado como erro
+	srv.Handle("DELETE", "/_search/scroll", 404, `{"succeeded":true,"num_freed":0}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it, err := srv.Client().Scroll(ctx, "logs-1", map[string]interface{}{"match_all": map[string]interface{}{}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := srv.Requests()[0].Query; q.Get("size") != "1000" || q.Get("scroll") != "1m" {
+		t.Errorf("unexpected query: %v", q)
+	}
+
+	// Close libera o contexto mesmo com ctx cancelado
+	cancel()
+	if err := it.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := it.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := it.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("Next after Close = %v, want io.EOF", err)
+	}
+
+	want := []string{"POST /logs-1/_search", "DELETE /_search/scroll"}
+	if got := writes(srv); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests %v, want %v", got, want)
+	}
+	assertJSON(t, srv.Requests()[1].Body, `{"scroll_id":["s1"]}`)
+}
@@ -0,0 +1,189 @@
+package opensearchmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Count retorna o número de documentos do índice que satisfazem a query;
+// query vazia equivale a match_all
+func (c *Client) Count(ctx context.Context, index string, query map[string]interface{}) (int64, error) {
+	var body interface{}
+	if len(query) > 0 {
+		body = map[string]interface{}{"query": query}
+	}
+
+	var result struct {
+		Count int64 `json:"count"`
+	}
+
+	path := fmt.Sprintf("/%s/_count", index)
+	if err := c.call(ctx, "POST", path, body, &result); err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	return result.Count, nil
+}
+
+// SearchResponse representa o resultado de uma busca
+type SearchResponse struct {
+	Took     int64
+	TimedOut bool
+	// TotalHits é o total de documentos encontrados; com
+	// TotalHitsRelation "gte" é apenas um limite inferior
+	TotalHits         int64
+	TotalHitsRelation string
+	// Hits traz cada hit completo (_index, _id, _source, sort, ...)
+	Hits []json.RawMessage
+	// Aggregations traz o bloco "aggregations" sem interpretação
+	Aggregations json.RawMessage
+	// PitID é o ID atualizado do point-in-time, quando usado
+	PitID string
+}
+
+// searchPayload é o formato da resposta de _search
+type searchPayload struct {
+	Took     int64 `json:"took"`
+	TimedOut bool  `json:"timed_out"`
+	Hits     struct {
+		Total json.RawMessage   `json:"total"`
+		Hits  []json.RawMessage `json:"hits"`
+	} `json:"hits"`
+	Aggregations json.RawMessage `json:"aggregations"`
+	PitID        string          `json:"pit_id"`
+}
+
+func (p searchPayload) response() *SearchResponse {
+	resp := &SearchResponse{
+		Took:         p.Took,
+		TimedOut:     p.TimedOut,
+		Hits:         p.Hits.Hits,
+		Aggregations: p.Aggregations,
+		PitID:        p.PitID,
+	}
+
+	// hits.total é um objeto {value, relation} ou, em versões antigas, um número
+	var total struct {
+		Value    int64  `json:"value"`
+		Relation string `json:"relation"`
+	}
+	if err := json.Unmarshal(p.Hits.Total, &total); err == nil {
+		resp.TotalHits = total.Value
+		resp.TotalHitsRelation = total.Relation
+	} else if err := json.Unmarshal(p.Hits.Total, &resp.TotalHits); err == nil {
+		resp.TotalHitsRelation = "eq"
+	}
+
+	return resp
+}
+
+// Search executa uma busca com corpo livre (query, aggs, sort, size, ...)
+func (c *Client) Search(ctx context.Context, index string, body map[string]interface{}) (*SearchResponse, error) {
+	var payload searchPayload
+	path := fmt.Sprintf("/%s/_search", index)
+	if err := c.call(ctx, "POST", path, body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	return payload.response(), nil
+}
+
+// IndexDateRange retorna o menor e o maior valor do campo de data no índice,
+// permitindo retenção pela data do documento mais recente em vez da data de
+// criação. Retorna erro se o campo não existir ou não tiver valores.
+func (c *Client) IndexDateRange(ctx context.Context, index, timestampField string) (min, max time.Time, err error) {
+	body := map[string]interface{}{
+		"size":             0,
+		"track_total_hits": false,
+		"aggs": map[string]interface{}{
+			"min_date": map[string]interface{}{"min": map[string]interface{}{"field": timestampField}},
+			"max_date": map[string]interface{}{"max": map[string]interface{}{"field": timestampField}},
+		},
+	}
+
+	resp, err := c.Search(ctx, index, body)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to get date range: %w", err)
+	}
+
+	// Campos ausentes ou sem valores retornam "value": null
+	var aggs struct {
+		Min struct {
+			Value *float64 `json:"value"`
+		} `json:"min_date"`
+		Max struct {
+			Value *float64 `json:"value"`
+		} `json:"max_date"`
+	}
+	if err := json.Unmarshal(resp.Aggregations, &aggs); err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to decode date range: %w", err)
+	}
+	if aggs.Min.Value == nil || aggs.Max.Value == nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("field %s is missing or has no values in index %s", timestampField, index)
+	}
+
+	return time.UnixMilli(int64(*aggs.Min.Value)).UTC(), time.UnixMilli(int64(*aggs.Max.Value)).UTC(), nil
+}
+
+// MgetItem identifica um documento a ser buscado por MultiGet
+type MgetItem struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
+
+// MgetResult é o resultado de um item de MultiGet, na mesma ordem da entrada
+type MgetResult struct {
+	Index   string
+	ID      string
+	Found   bool
+	Version int64
+	Source  json.RawMessage
+	// Error traz a falha do item (ex.: índice inexistente); documentos
+	// inexistentes apenas retornam Found false
+	Error *MgetItemError
+}
+
+// MgetItemError representa o erro de um item do _mget
+type MgetItemError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// MultiGet busca vários documentos por índice e ID em uma única chamada _mget
+func (c *Client) MultiGet(ctx context.Context, items []MgetItem) ([]MgetResult, error) {
+	if len(items) == 0 {
+		return []MgetResult{}, nil
+	}
+
+	var payload struct {
+		Docs []struct {
+			Index   string          `json:"_index"`
+			ID      string          `json:"_id"`
+			Found   bool            `json:"found"`
+			Version int64           `json:"_version"`
+			Source  json.RawMessage `json:"_source"`
+			Error   *MgetItemError  `json:"error"`
+		} `json:"docs"`
+	}
+
+	body := map[string]interface{}{"docs": items}
+	if err := c.call(ctx, "POST", "/_mget", body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+
+	results := make([]MgetResult, 0, len(payload.Docs))
+	for _, doc := range payload.Docs {
+		results = append(results, MgetResult{
+			Index:   doc.Index,
+			ID:      doc.ID,
+			Found:   doc.Found,
+			Version: doc.Version,
+			Source:  doc.Source,
+			Error:   doc.Error,
+		})
+	}
+
+	return results, nil
+}
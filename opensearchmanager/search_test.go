@@ -0,0 +1,43 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"testing"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager"
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+func TestMultiGet(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("POST", "/_mget", 200, `{"docs":[
+		{"_index":"logs-1","_id":"a","_version":2,"found":true,"_source":{"msg":"hi"}},
+		{"_index":"logs-1","_id":"b","found":false},
+		{"_index":"missing","_id":"c","error":{"type":"index_not_found_exception","reason":"no such index [missing]"}}
+	]}`)
+
+	results, err := srv.Client().MultiGet(context.Background(), []opensearchmanager.MgetItem{
+		{Index: "logs-1", ID: "a"}, {Index: "logs-1", ID: "b"}, {Index: "missing", ID: "c"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if body := string(srv.Requests()[0].Body); body != `{"docs":[{"_index":"logs-1","_id":"a"},{"_index":"logs-1","_id":"b"},{"_index":"missing","_id":"c"}]}` {
+		t.Errorf("unexpected body: %s", body)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	if !results[0].Found || results[0].Version != 2 || string(results[0].Source) != `{"msg":"hi"}` {
+		t.Errorf("unexpected found result: %+v", results[0])
+	}
+	if results[1].Found || results[1].Error != nil {
+		t.Errorf("missing document must only report Found false: %+v", results[1])
+	}
+	want := opensearchmanager.MgetItemError{Type: "index_not_found_exception", Reason: "no such index [missing]"}
+	if results[2].Error == nil || *results[2].Error != want {
+		t.Errorf("unexpected item error: %+v", results[2].Error)
+	}
+}
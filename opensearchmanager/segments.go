@@ -0,0 +1,89 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// SegmentInfo resume os segmentos de uma cópia de shard. Um índice em que
+// todos os shards têm um único segmento não ganha nada com force-merge.
+type SegmentInfo struct {
+	Index   string
+	Shard   int
+	Primary bool
+	Node    string
+
+	// Segments é o total de segmentos da cópia
+	Segments int
+	// CommittedSegments já foram persistidos em disco por um flush
+	CommittedSegments int
+	// SearchSegments estão visíveis para busca
+	SearchSegments int
+
+	SizeBytes   int64
+	DocsCount   int64
+	DeletedDocs int64
+}
+
+// Segments lista os segmentos de cada cópia de shard do índice via _segments
+func (c *Client) Segments(ctx context.Context, index string) ([]SegmentInfo, error) {
+	var payload struct {
+		Indices map[string]struct {
+			Shards map[string][]struct {
+				Routing struct {
+					Primary bool   `json:"primary"`
+					Node    string `json:"node"`
+				} `json:"routing"`
+				NumCommittedSegments int `json:"num_committed_segments"`
+				NumSearchSegments    int `json:"num_search_segments"`
+				Segments             map[string]struct {
+					NumDocs     int64 `json:"num_docs"`
+					DeletedDocs int64 `json:"deleted_docs"`
+					SizeInBytes int64 `json:"size_in_bytes"`
+				} `json:"segments"`
+			} `json:"shards"`
+		} `json:"indices"`
+	}
+
+	path := fmt.Sprintf("/%s/_segments", index)
+	if err := c.call(ctx, "GET", path, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to get segments: %w", err)
+	}
+
+	result := []SegmentInfo{}
+	for name, idx := range payload.Indices {
+		for shardID, copies := range idx.Shards {
+			shard := atoiOrZero(shardID)
+			for _, replica := range copies {
+				info := SegmentInfo{
+					Index:             name,
+					Shard:             shard,
+					Primary:           replica.Routing.Primary,
+					Node:              replica.Routing.Node,
+					Segments:          len(replica.Segments),
+					CommittedSegments: replica.NumCommittedSegments,
+					SearchSegments:    replica.NumSearchSegments,
+				}
+				for _, segment := range replica.Segments {
+					info.SizeBytes += segment.SizeInBytes
+					info.DocsCount += segment.NumDocs
+					info.DeletedDocs += segment.DeletedDocs
+				}
+				result = append(result, info)
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Index != result[j].Index {
+			return result[i].Index < result[j].Index
+		}
+		if result[i].Shard != result[j].Shard {
+			return result[i].Shard < result[j].Shard
+		}
+		return result[i].Primary && !result[j].Primary
+	})
+
+	return result, nil
+}
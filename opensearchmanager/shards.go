@@ -0,0 +1,71 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ShardInfo representa a alocação de uma cópia de shard
+type ShardInfo struct {
+	Index   string
+	Shard   int
+	Primary bool
+	// State é STARTED, RELOCATING, INITIALIZING ou UNASSIGNED
+	State string
+	// Node fica vazio para shards não alocados
+	Node string
+	// UnassignedReason explica por que o shard não está alocado
+	// (ex.: NODE_LEFT, ALLOCATION_FAILED)
+	UnassignedReason string
+	Docs             int64
+	StoreBytes       int64
+}
+
+// catShardsColumns são as colunas pedidas ao _cat/shards
+const catShardsColumns = "index,shard,prirep,state,node,unassigned.reason,docs,store"
+
+// ListShards lista as cópias de shard dos índices que correspondem ao padrão
+// (vazio lista todos), útil para encontrar shards não alocados antes de
+// operar sobre um índice
+func (c *Client) ListShards(ctx context.Context, indexPattern string) ([]ShardInfo, error) {
+	var rows []struct {
+		Index            string `json:"index"`
+		Shard            string `json:"shard"`
+		PriRep           string `json:"prirep"`
+		State            string `json:"state"`
+		Node             string `json:"node"`
+		UnassignedReason string `json:"unassigned.reason"`
+		Docs             string `json:"docs"`
+		Store            string `json:"store"`
+	}
+
+	path := "/_cat/shards"
+	if indexPattern != "" {
+		path += "/" + indexPattern
+	}
+	path += "?format=json&bytes=b&h=" + catShardsColumns
+
+	if err := c.call(ctx, "GET", path, nil, &rows); err != nil {
+		return nil, fmt.Errorf("failed to list shards: %w", err)
+	}
+
+	shards := make([]ShardInfo, 0, len(rows))
+	for _, row := range rows {
+		// Shards não alocados retornam docs/store nulos
+		docs, _ := strconv.ParseInt(row.Docs, 10, 64)
+		store, _ := strconv.ParseInt(row.Store, 10, 64)
+		shards = append(shards, ShardInfo{
+			Index:            row.Index,
+			Shard:            atoiOrZero(row.Shard),
+			Primary:          row.PriRep == "p",
+			State:            row.State,
+			Node:             row.Node,
+			UnassignedReason: row.UnassignedReason,
+			Docs:             docs,
+			StoreBytes:       store,
+		})
+	}
+
+	return shards, nil
+}
@@ -0,0 +1,294 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager"
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+const sourceSettings = `{"logs-1":{"settings":{"index.number_of_shards":"4","index.number_of_replicas":"1"}}}`
+
+func TestShrinkIndexExistingTarget(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/logs-1/_settings", 200, sourceSettings)
+	srv.Handle("HEAD", "/logs-1-shrunk", 200, "")
+
+	err := srv.Client().ShrinkIndex(context.Background(), "logs-1", "logs-1-shrunk", map[string]interface{}{"number_of_shards": 1})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected already exists error, got %v", err)
+	}
+
+	// Nada além das leituras: o source não é fechado e o target não é removido
+	if got := writes(srv); len(got) != 0 {
+		t.Errorf("unexpected requests %v", got)
+	}
+}
+
+func TestShrinkIndexAlreadyExistsRace(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/logs-1/_settings", 200, sourceSettings)
+	srv.Handle("POST", "/logs-1/_close", 200, `{"acknowledged":true}`)
+	srv.Handle("POST", "/logs-1/_shrink/logs-1-shrunk", 400,
+		`{"error":{"type":"resource_already_exists_exception","reason":"index [logs-1-shrunk] already exists"},"status":400}`)
+	srv.Handle("POST", "/logs-1/_open", 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+	srv.Handle("PUT", "/logs-1/_settings", 200, `{"acknowledged":true}`)
+
+	err := srv.Client().ShrinkIndex(context.Background(), "logs-1", "logs-1-shrunk", map[string]interface{}{"number_of_shards": 1})
+	if err == nil {
+		t.Fatal("expected shrink error")
+	}
+
+	var reopened bool
+	for _, req := range srv.Requests() {
+		if req.Method == "DELETE" {
+			t.Errorf("target created by someone else must not be deleted: %s %s", req.Method, req.Path)
+		}
+		if req.Method == "POST" && req.Path == "/logs-1/_open" {
+			reopened = true
+		}
+	}
+	if !reopened {
+		t.Error("source index was not reopened by the rollback")
+	}
+}
+
+func TestShrinkIndex(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/logs-1/_settings", 200, sourceSettings)
+	srv.Handle("HEAD", "/logs-1-shrunk", 404, "")
+	srv.Handle("HEAD", "/logs-1-shrunk", 200, "")
+	srv.Handle("POST", "/logs-1/_close", 200, `{"acknowledged":true}`)
+	srv.Handle("POST", "/logs-1/_shrink/logs-1-shrunk", 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+	srv.Handle("POST", "/logs-1/_open", 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+	srv.Handle("POST", "/logs-1-shrunk/_open", 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+	srv.Handle("GET", "/_cluster/health/logs-1-shrunk", 200, `{"status":"green"}`)
+	srv.Handle("PUT", "/logs-1-shrunk/_settings", 200, `{"acknowledged":true}`)
+
+	err := srv.Client().ShrinkIndex(context.Background(), "logs-1", "logs-1-shrunk", map[string]interface{}{"number_of_shards": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"POST /logs-1/_close",
+		"POST /logs-1/_shrink/logs-1-shrunk",
+		"POST /logs-1/_open",
+		"POST /logs-1-shrunk/_open",
+		"PUT /logs-1-shrunk/_settings",
+	}
+	if got := writes(srv); !reflect.DeepEqual(got, want) {
+		t.Fatalf("requests %v, want %v", got, want)
+	}
+
+	for _, req := range srv.Requests() {
+		switch req.Method + " " + req.Path {
+		case "POST /logs-1/_shrink/logs-1-shrunk":
+			var body struct {
+				Settings map[string]interface{} `json:"settings"`
+			}
+			if err := json.Unmarshal(req.Body, &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Settings["index.number_of_shards"] != float64(1) || body.Settings["index.number_of_replicas"] != float64(0) {
+				t.Errorf("unexpected shrink settings: %v", body.Settings)
+			}
+		case "PUT /logs-1-shrunk/_settings":
+			// Sem réplicas informadas o target herda as do source
+			if !strings.Contains(string(req.Body), `"index.number_of_replicas":"1"`) {
+				t.Errorf("unexpected final settings: %s", req.Body)
+			}
+		}
+	}
+}
+
+func TestShrinkIndexRollback(t *testing.T) {
+	tests := []struct {
+		name      string
+		protected []string
+		wantReqs  []string
+		wantErr   string
+	}{
+		{
+			name: "deletes partial target",
+			wantReqs: []string{
+				"POST /logs-1/_close",
+				"POST /logs-1/_shrink/logs-1-shrunk",
+				"DELETE /logs-1-shrunk",
+				"POST /logs-1/_open",
+				"PUT /logs-1/_settings",
+			},
+			wantErr: "shrink failed",
+		},
+		{
+			name:      "protected target is kept",
+			protected: []string{"logs-1-shrunk"},
+			wantReqs: []string{
+				"POST /logs-1/_close",
+				"POST /logs-1/_shrink/logs-1-shrunk",
+				"POST /logs-1/_open",
+				"PUT /logs-1/_settings",
+			},
+			wantErr: "rollback incomplete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle("GET", "/logs-1/_settings", 200, sourceSettings)
+			srv.Handle("POST", "/logs-1/_close", 200, `{"acknowledged":true}`)
+			srv.Handle("POST", "/logs-1/_shrink/logs-1-shrunk", 500, `{"error":{"type":"exception","reason":"boom"},"status":500}`)
+			srv.Handle("DELETE", "/logs-1-shrunk", 200, `{"acknowledged":true}`)
+			srv.Handle("POST", "/logs-1/_open", 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+			srv.Handle("PUT", "/logs-1/_settings", 200, `{"acknowledged":true}`)
+
+			client := srv.Client()
+			client.ProtectedPatterns = tt.protected
+
+			err := client.ShrinkIndex(context.Background(), "logs-1", "logs-1-shrunk", map[string]interface{}{"number_of_shards": 2})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("got error %v, want %q", err, tt.wantErr)
+			}
+			if got := writes(srv); !reflect.DeepEqual(got, tt.wantReqs) {
+				t.Errorf("requests %v, want %v", got, tt.wantReqs)
+			}
+		})
+	}
+}
+
+func TestShrinkIndexKeepSourceOpen(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/logs-1/_settings", 200, sourceSettings)
+	srv.Handle("HEAD", "/logs-1-shrunk", 404, "")
+	srv.Handle("HEAD", "/logs-1-shrunk", 200, "")
+	srv.Handle("PUT", "/logs-1/_settings", 200, `{"acknowledged":true}`)
+	srv.Handle("POST", "/logs-1/_shrink/logs-1-shrunk", 200, `{"acknowledged":true,"shards_acknowledged":true}`)
+	srv.Handle("GET", "/_cluster/health/logs-1-shrunk", 200, `{"status":"green"}`)
+	srv.Handle("PUT", "/logs-1-shrunk/_settings", 200, `{"acknowledged":true}`)
+
+	opts := opensearchmanager.ShrinkOptions{KeepSourceOpen: true}
+	err := srv.Client().ShrinkIndexWithOptions(context.Background(), "logs-1", "logs-1-shrunk", map[string]interface{}{"number_of_shards": 1}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// O source nunca é fechado: bloqueio de escrita, shrink e restauração
+	want := []string{
+		"PUT /logs-1/_settings",
+		"POST /logs-1/_shrink/logs-1-shrunk",
+		"PUT /logs-1/_settings",
+		"PUT /logs-1-shrunk/_settings",
+	}
+	if got := writes(srv); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests %v, want %v", got, want)
+	}
+}
+
+func TestShrinkIndexAcknowledgement(t *testing.T) {
+	const (
+		acked         = `{"acknowledged":true,"shards_acknowledged":true}`
+		shardsPending = `{"acknowledged":true,"shards_acknowledged":false}`
+		notAcked      = `{"acknowledged":false,"shards_acknowledged":false}`
+	)
+
+	tests := []struct {
+		name       string
+		closeBody  string
+		targetOpen string
+		wantErr    bool
+		wantReqs   []string
+	}{
+		{
+			name:       "close shards pending",
+			closeBody:  shardsPending,
+			targetOpen: acked,
+			wantReqs: []string{
+				"POST /logs-1/_close",
+				"POST /logs-1/_shrink/logs-1-shrunk",
+				"POST /logs-1/_open",
+				"POST /logs-1-shrunk/_open",
+				"PUT /logs-1-shrunk/_settings",
+			},
+		},
+		{
+			// O close pode ter sido aplicado: o source é reaberto, sem target a remover
+			name:       "close not acknowledged",
+			closeBody:  notAcked,
+			targetOpen: acked,
+			wantErr:    true,
+			wantReqs: []string{
+				"POST /logs-1/_close",
+				"POST /logs-1/_open",
+				"PUT /logs-1/_settings",
+			},
+		},
+		{
+			// O target é válido; WaitForGreen confirma a prontidão
+			name:       "target open shards pending",
+			closeBody:  acked,
+			targetOpen: shardsPending,
+			wantReqs: []string{
+				"POST /logs-1/_close",
+				"POST /logs-1/_shrink/logs-1-shrunk",
+				"POST /logs-1/_open",
+				"POST /logs-1-shrunk/_open",
+				"PUT /logs-1-shrunk/_settings",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle("GET", "/logs-1/_settings", 200, sourceSettings)
+			srv.Handle("HEAD", "/logs-1-shrunk", 404, "")
+			srv.Handle("HEAD", "/logs-1-shrunk", 200, "")
+			srv.Handle("POST", "/logs-1/_close", 200, tt.closeBody)
+			srv.Handle("POST", "/logs-1/_shrink/logs-1-shrunk", 200, acked)
+			srv.Handle("POST", "/logs-1/_open", 200, acked)
+			srv.Handle("POST", "/logs-1-shrunk/_open", 200, tt.targetOpen)
+			srv.Handle("GET", "/_cluster/health/logs-1-shrunk", 200, `{"status":"green"}`)
+			srv.Handle("PUT", "/logs-1/_settings", 200, `{"acknowledged":true}`)
+			srv.Handle("PUT", "/logs-1-shrunk/_settings", 200, `{"acknowledged":true}`)
+
+			err := srv.Client().ShrinkIndex(context.Background(), "logs-1", "logs-1-shrunk", map[string]interface{}{"number_of_shards": 2})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := writes(srv); !reflect.DeepEqual(got, tt.wantReqs) {
+				t.Errorf("requests %v, want %v", got, tt.wantReqs)
+			}
+		})
+	}
+}
+
+func TestShrinkIndexDryRun(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/logs-1/_settings", 200, sourceSettings)
+
+	client := srv.Client()
+	client.DryRun = true
+	if err := client.ShrinkIndex(context.Background(), "logs-1", "logs-1-shrunk", map[string]interface{}{"number_of_shards": 2}); err != nil {
+		t.Fatal(err)
+	}
+	if got := writes(srv); len(got) != 0 {
+		t.Errorf("dry run sent %v", got)
+	}
+
+	// As validações continuam valendo em DryRun
+	if err := client.ShrinkIndex(context.Background(), "logs-1", "logs-1-shrunk", map[string]interface{}{"number_of_shards": 3}); err == nil {
+		t.Error("expected shard count validation error")
+	}
+}
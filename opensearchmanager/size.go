@@ -0,0 +1,44 @@
+package opensearchmanager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// byteUnits mapeia os sufixos usados pelas APIs _cat para múltiplos de bytes
+var byteUnits = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"pb", 1 << 50},
+	{"tb", 1 << 40},
+	{"gb", 1 << 30},
+	{"mb", 1 << 20},
+	{"kb", 1 << 10},
+	{"b", 1},
+}
+
+// parseByteSize converte tamanhos legíveis ("4.2gb", "230mb", "512") em bytes
+func parseByteSize(s string) (int64, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	if value == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	multiplier := 1.0
+	for _, unit := range byteUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	return int64(n * multiplier), nil
+}
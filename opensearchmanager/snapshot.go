@@ -0,0 +1,185 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SnapshotRequest representa o corpo de criação de um snapshot
+type SnapshotRequest struct {
+	Indices            []string `json:"indices,omitempty"`
+	IgnoreUnavailable  bool     `json:"ignore_unavailable,omitempty"`
+	IncludeGlobalState *bool    `json:"include_global_state,omitempty"`
+	Partial            bool     `json:"partial,omitempty"`
+
+	// WaitForCompletion bloqueia a chamada até o snapshot terminar
+	WaitForCompletion bool `json:"-"`
+	// FlushBeforeSnapshot executa um flush nos índices, aliases e data streams
+	// do snapshot (ou em todos, se Indices estiver vazio) antes de criá-lo
+	FlushBeforeSnapshot bool `json:"-"`
+}
+
+// CreateSnapshot cria um snapshot em um repositório já registrado
+func (c *Client) CreateSnapshot(ctx context.Context, repository, snapshot string, body SnapshotRequest) error {
+	if body.FlushBeforeSnapshot {
+		// A expressão vai direto ao servidor, que resolve aliases e data
+		// streams; índices fechados ou ausentes não impedem o snapshot
+		target := "_all"
+		if len(body.Indices) > 0 {
+			target = strings.Join(body.Indices, ",")
+		}
+		path := withRequestOptions(fmt.Sprintf("/%s/_flush", target), []RequestOption{
+			WithIgnoreUnavailable(true),
+			WithAllowNoIndices(true),
+			WithParam("wait_if_ongoing", "true"),
+		})
+		if err := c.call(ctx, "POST", path, nil, nil); err != nil {
+			return fmt.Errorf("failed to flush before snapshot: %w", err)
+		}
+	}
+
+	path := fmt.Sprintf("/_snapshot/%s/%s", repository, snapshot)
+	if body.WaitForCompletion {
+		path += "?wait_for_completion=true"
+	}
+
+	if err := c.call(ctx, "POST", path, body, nil); err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreRequest representa o corpo de restauração de um snapshot
+type RestoreRequest struct {
+	Indices           []string               `json:"indices"`
+	RenamePattern     string                 `json:"rename_pattern,omitempty"`
+	RenameReplacement string                 `json:"rename_replacement,omitempty"`
+	IncludeAliases    *bool                  `json:"include_aliases,omitempty"`
+	IndexSettings     map[string]interface{} `json:"index_settings,omitempty"`
+}
+
+// RestoreSnapshot restaura índices de um snapshot, opcionalmente renomeando-os
+func (c *Client) RestoreSnapshot(ctx context.Context, repository, snapshot string, body RestoreRequest) error {
+	if len(body.Indices) == 0 {
+		return fmt.Errorf("restore requires at least one index pattern")
+	}
+
+	path := fmt.Sprintf("/_snapshot/%s/%s/_restore", repository, snapshot)
+	if err := c.call(ctx, "POST", path, body, nil); err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// SnapshotInfo representa informações básicas de um snapshot
+type SnapshotInfo struct {
+	Name      string
+	State     string
+	StartTime time.Time
+	EndTime   time.Time
+	Duration  time.Duration
+	Indices   []string
+}
+
+// ListSnapshots retorna todos os snapshots de um repositório
+func (c *Client) ListSnapshots(ctx context.Context, repository string) ([]SnapshotInfo, error) {
+	var payload struct {
+		Snapshots []struct {
+			Snapshot          string   `json:"snapshot"`
+			State             string   `json:"state"`
+			Indices           []string `json:"indices"`
+			StartTimeInMillis int64    `json:"start_time_in_millis"`
+			EndTimeInMillis   int64    `json:"end_time_in_millis"`
+			DurationInMillis  int64    `json:"duration_in_millis"`
+		} `json:"snapshots"`
+	}
+
+	path := fmt.Sprintf("/_snapshot/%s/_all", repository)
+	if err := c.call(ctx, "GET", path, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	var result []SnapshotInfo
+	for _, snap := range payload.Snapshots {
+		info := SnapshotInfo{
+			Name:     snap.Snapshot,
+			State:    snap.State,
+			Duration: time.Duration(snap.DurationInMillis) * time.Millisecond,
+			Indices:  snap.Indices,
+		}
+		if snap.StartTimeInMillis > 0 {
+			info.StartTime = time.UnixMilli(snap.StartTimeInMillis)
+		}
+		if snap.EndTimeInMillis > 0 {
+			info.EndTime = time.UnixMilli(snap.EndTimeInMillis)
+		}
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+// DeleteSnapshot exclui um snapshot do repositório
+func (c *Client) DeleteSnapshot(ctx context.Context, repository, snapshot string) error {
+	path := fmt.Sprintf("/_snapshot/%s/%s", repository, snapshot)
+	if err := c.call(ctx, "DELETE", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterRepository registra (ou atualiza) um repositório de snapshots do
+// tipo informado (ex.: "fs", "s3") com as configurações do tipo
+func (c *Client) RegisterRepository(ctx context.Context, name, repoType string, settings map[string]interface{}) error {
+	body := map[string]interface{}{
+		"type":     repoType,
+		"settings": settings,
+	}
+
+	path := fmt.Sprintf("/_snapshot/%s", name)
+	if err := c.call(ctx, "PUT", path, body, nil); err != nil {
+		return fmt.Errorf("failed to register repository: %w", err)
+	}
+	return nil
+}
+
+// VerifyRepository confirma que todos os nós conseguem acessar o repositório
+func (c *Client) VerifyRepository(ctx context.Context, repository string) error {
+	path := fmt.Sprintf("/_snapshot/%s/_verify", repository)
+	if err := c.call(ctx, "POST", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to verify repository: %w", err)
+	}
+	return nil
+}
+
+// RepoCleanupResult representa o resultado da limpeza de um repositório
+type RepoCleanupResult struct {
+	DeletedBytes int64
+	DeletedBlobs int64
+}
+
+// CleanupRepository remove dados não referenciados do repositório, liberando
+// espaço após a exclusão de snapshots antigos
+func (c *Client) CleanupRepository(ctx context.Context, repository string) (*RepoCleanupResult, error) {
+	var payload struct {
+		Results struct {
+			DeletedBytes int64 `json:"deleted_bytes"`
+			DeletedBlobs int64 `json:"deleted_blobs"`
+		} `json:"results"`
+	}
+
+	path := fmt.Sprintf("/_snapshot/%s/_cleanup", repository)
+	if err := c.call(ctx, "POST", path, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to cleanup repository: %w", err)
+	}
+
+	return &RepoCleanupResult{
+		DeletedBytes: payload.Results.DeletedBytes,
+		DeletedBlobs: payload.Results.DeletedBlobs,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager"
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+func TestCreateSnapshotFlush(t *testing.T) {
+	tests := []struct {
+		name      string
+		indices   []string
+		flushPath string
+	}{
+		{"all indices", nil, "/_all/_flush"},
+		// Aliases e data streams são resolvidos pelo servidor
+		{"aliases and data streams", []string{"logs-write", "metrics-ds"}, "/logs-write,metrics-ds/_flush"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			srv.Handle("POST", tt.flushPath, 200, `{"_shards":{"total":0,"successful":0,"failed":0}}`)
+			srv.Handle("POST", "/_snapshot/repo/snap-1", 200, `{"accepted":true}`)
+
+			err := srv.Client().CreateSnapshot(context.Background(), "repo", "snap-1", opensearchmanager.SnapshotRequest{
+				Indices:             tt.indices,
+				FlushBeforeSnapshot: true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := []string{"POST " + tt.flushPath, "POST /_snapshot/repo/snap-1"}
+			if got := writes(srv); !reflect.DeepEqual(got, want) {
+				t.Fatalf("requests %v, want %v", got, want)
+			}
+			query := srv.Requests()[0].Query
+			for _, param := range []string{"ignore_unavailable", "allow_no_indices", "wait_if_ongoing"} {
+				if query.Get(param) != "true" {
+					t.Errorf("flush query %v: %s should be true", query, param)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package opensearchmanager
+
+import (
+	"context"
+	"fmt"
+)
+
+// IndexStats reúne as estatísticas de um índice para primários e total (com réplicas)
+type IndexStats struct {
+	Index     string
+	Primaries StatsSummary
+	Total     StatsSummary
+}
+
+// StatsSummary representa um bloco de estatísticas (primaries ou total)
+type StatsSummary struct {
+	DocsCount      int64
+	DocsDeleted    int64
+	StoreSizeBytes int64
+	// SegmentCount indica se um force-merge ainda compensa
+	SegmentCount         int64
+	MergesCurrent        int64
+	MergesTotal          int64
+	MergesTotalTimeMs    int64
+	MergesTotalSizeBytes int64
+}
+
+// statsPayload representa um bloco primaries/total da API _stats
+type statsPayload struct {
+	Docs struct {
+		Count   int64 `json:"count"`
+		Deleted int64 `json:"deleted"`
+	} `json:"docs"`
+	Store struct {
+		SizeInBytes int64 `json:"size_in_bytes"`
+	} `json:"store"`
+	Segments struct {
+		Count int64 `json:"count"`
+	} `json:"segments"`
+	Merges struct {
+		Current           int64 `json:"current"`
+		Total             int64 `json:"total"`
+		TotalTimeInMillis int64 `json:"total_time_in_millis"`
+		TotalSizeInBytes  int64 `json:"total_size_in_bytes"`
+	} `json:"merges"`
+}
+
+func (p statsPayload) summary() StatsSummary {
+	return StatsSummary{
+		DocsCount:            p.Docs.Count,
+		DocsDeleted:          p.Docs.Deleted,
+		StoreSizeBytes:       p.Store.SizeInBytes,
+		SegmentCount:         p.Segments.Count,
+		MergesCurrent:        p.Merges.Current,
+		MergesTotal:          p.Merges.Total,
+		MergesTotalTimeMs:    p.Merges.TotalTimeInMillis,
+		MergesTotalSizeBytes: p.Merges.TotalSizeInBytes,
+	}
+}
+
+// IndexStats retorna estatísticas de documentos, armazenamento, segmentos e
+// merges de um índice. Para padrões, os valores são agregados.
+func (c *Client) IndexStats(ctx context.Context, index string) (*IndexStats, error) {
+	type block struct {
+		Primaries statsPayload `json:"primaries"`
+		Total     statsPayload `json:"total"`
+	}
+	var payload struct {
+		All     block            `json:"_all"`
+		Indices map[string]block `json:"indices"`
+	}
+
+	path := fmt.Sprintf("/%s/_stats/docs,store,segments,merges", index)
+	if err := c.call(ctx, "GET", path, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to get index stats: %w", err)
+	}
+
+	stats, ok := payload.Indices[index]
+	if !ok {
+		stats = payload.All
+	}
+
+	return &IndexStats{
+		Index:     index,
+		Primaries: stats.Primaries.summary(),
+		Total:     stats.Total.summary(),
+	}, nil
+}
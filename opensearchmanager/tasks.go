@@ -0,0 +1,132 @@
+package opensearchmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TaskStatus representa o estado de uma task assíncrona do OpenSearch
+type TaskStatus struct {
+	Completed   bool
+	Action      string
+	Description string
+
+	// Contadores de progresso extraídos de task.status
+	Total            int64
+	Created          int64
+	Updated          int64
+	Deleted          int64
+	VersionConflicts int64
+
+	// Error traz o erro registrado pela task, se houver
+	Error *TaskError
+	// Failures lista as falhas por documento reportadas no resultado final
+	Failures []json.RawMessage
+	// Response traz o resultado final quando a task termina
+	Response json.RawMessage
+}
+
+// TaskError representa o erro registrado por uma task
+type TaskError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// Error implementa a interface error
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Type, e.Reason)
+}
+
+// GetTask consulta o estado e o progresso de uma task em /_tasks/{taskID}
+func (c *Client) GetTask(ctx context.Context, taskID string) (*TaskStatus, error) {
+	var payload struct {
+		Completed bool `json:"completed"`
+		Task      struct {
+			Action      string `json:"action"`
+			Description string `json:"description"`
+			Status      struct {
+				Total            int64 `json:"total"`
+				Created          int64 `json:"created"`
+				Updated          int64 `json:"updated"`
+				Deleted          int64 `json:"deleted"`
+				VersionConflicts int64 `json:"version_conflicts"`
+			} `json:"status"`
+		} `json:"task"`
+		Response json.RawMessage `json:"response"`
+		Error    *TaskError      `json:"error"`
+	}
+
+	path := fmt.Sprintf("/_tasks/%s", taskID)
+	if err := c.call(ctx, "GET", path, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	status := &TaskStatus{
+		Completed:        payload.Completed,
+		Action:           payload.Task.Action,
+		Description:      payload.Task.Description,
+		Total:            payload.Task.Status.Total,
+		Created:          payload.Task.Status.Created,
+		Updated:          payload.Task.Status.Updated,
+		Deleted:          payload.Task.Status.Deleted,
+		VersionConflicts: payload.Task.Status.VersionConflicts,
+		Error:            payload.Error,
+		Response:         payload.Response,
+	}
+
+	if len(payload.Response) > 0 {
+		var response struct {
+			Failures []json.RawMessage `json:"failures"`
+		}
+		if err := json.Unmarshal(payload.Response, &response); err == nil {
+			status.Failures = response.Failures
+		}
+	}
+
+	return status, nil
+}
+
+// CancelTask solicita o cancelamento de uma task em execução
+func (c *Client) CancelTask(ctx context.Context, taskID string) error {
+	path := fmt.Sprintf("/_tasks/%s/_cancel", taskID)
+	if err := c.call(ctx, "POST", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to cancel task: %w", err)
+	}
+	return nil
+}
+
+// maxTaskPollInterval limita o crescimento do intervalo em WaitForTask
+const maxTaskPollInterval = 30 * time.Second
+
+// WaitForTask consulta a task até que ela termine ou o contexto expire,
+// dobrando o intervalo entre consultas a partir de pollInterval (até 30s).
+// Erros e falhas registrados pela própria task (ex.: conflitos de versão)
+// vêm no status retornado, sem virar erro; ao expirar o contexto o último
+// status conhecido é retornado junto com o erro.
+func (c *Client) WaitForTask(ctx context.Context, taskID string, pollInterval time.Duration) (*TaskStatus, error) {
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+
+	interval := pollInterval
+	var last *TaskStatus
+	for {
+		status, err := c.GetTask(ctx, taskID)
+		if err != nil {
+			return last, err
+		}
+		if status.Completed {
+			return status, nil
+		}
+		last = status
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return last, fmt.Errorf("task %s did not complete: %w", taskID, err)
+		}
+		if interval *= 2; interval > maxTaskPollInterval {
+			interval = maxTaskPollInterval
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package opensearchmanager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+const runningTask = `{"completed":false,"task":{"action":"indices:data/write/reindex","description":"reindex [logs-1] to [logs-new]",` +
+	`"status":{"total":100,"created":40,"updated":0,"deleted":0,"version_conflicts":0}}}`
+
+func TestGetTask(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/_tasks/node-1:42", 200, `{"completed":true,"task":{"action":"indices:data/write/reindex",`+
+		`"status":{"total":100,"created":98,"updated":0,"deleted":0,"version_conflicts":2}},`+
+		`"response":{"total":100,"created":98,"version_conflicts":2,"failures":[{"id":"a"},{"id":"b"}]}}`)
+
+	status, err := srv.Client().GetTask(context.Background(), "node-1:42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Completed || status.Total != 100 || status.Created != 98 || status.VersionConflicts != 2 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if len(status.Failures) != 2 || status.Error != nil {
+		t.Errorf("unexpected failures/error: %v %v", status.Failures, status.Error)
+	}
+}
+
+func TestWaitForTask(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/_tasks/node-1:42", 200, runningTask)
+	srv.Handle("GET", "/_tasks/node-1:42", 200, runningTask)
+	srv.Handle("GET", "/_tasks/node-1:42", 200, `{"completed":true,"task":{"action":"indices:data/write/reindex"},`+
+		`"error":{"type":"search_phase_execution_exception","reason":"all shards failed"}}`)
+
+	status, err := srv.Client().WaitForTask(context.Background(), "node-1:42", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// O erro da própria task vem no status, sem virar erro da chamada
+	if !status.Completed || status.Error == nil || status.Error.Type != "search_phase_execution_exception" {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("got %d polls, want 3", n)
+	}
+}
+
+func TestWaitForTaskDeadline(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/_tasks/node-1:42", 200, runningTask)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	status, err := srv.Client().WaitForTask(ctx, "node-1:42", 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want deadline exceeded", err)
+	}
+	// O último status conhecido acompanha o erro
+	if status == nil || status.Completed || status.Created != 40 {
+		t.Errorf("unexpected last status: %+v", status)
+	}
+}
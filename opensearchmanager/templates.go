@@ -0,0 +1,35 @@
+package opensearchmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// PutIndexTemplate cria ou atualiza um index template componível (_index_template)
+func (c *Client) PutIndexTemplate(ctx context.Context, name string, body map[string]interface{}) error {
+	path := fmt.Sprintf("/_index_template/%s", name)
+	if err := c.call(ctx, "PUT", path, body, nil); err != nil {
+		return fmt.Errorf("failed to put index template: %w", err)
+	}
+	return nil
+}
+
+// GetIndexTemplate retorna a definição bruta de um index template
+func (c *Client) GetIndexTemplate(ctx context.Context, name string) (json.RawMessage, error) {
+	var raw json.RawMessage
+	path := fmt.Sprintf("/_index_template/%s", name)
+	if err := c.call(ctx, "GET", path, nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get index template: %w", err)
+	}
+	return raw, nil
+}
+
+// DeleteIndexTemplate remove um index template
+func (c *Client) DeleteIndexTemplate(ctx context.Context, name string) error {
+	path := fmt.Sprintf("/_index_template/%s", name)
+	if err := c.call(ctx, "DELETE", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete index template: %w", err)
+	}
+	return nil
+}
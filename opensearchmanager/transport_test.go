@@ -0,0 +1,323 @@
+package opensearchmanager_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"kartmatias/go-opensearch-curator/opensearchmanager"
+	"kartmatias/go-opensearch-curator/opensearchmanager/opensearchtest"
+)
+
+func TestRetry(t *testing.T) {
+	unavailable := opensearchtest.Response{StatusCode: 503, Body: `{"error":"unavailable"}`}
+	retryNow := opensearchtest.Response{StatusCode: 503, Body: `{"error":"unavailable"}`, Header: http.Header{"Retry-After": {"0"}}}
+	retryLater := opensearchtest.Response{StatusCode: 503, Body: `{"error":"unavailable"}`, Header: http.Header{"Retry-After": {"60"}}}
+	badRequest := opensearchtest.Response{StatusCode: 400, Body: `{"error":"bad request"}`}
+	ok := opensearchtest.Response{StatusCode: 200, Body: `{"status":"green"}`}
+
+	tests := []struct {
+		name      string
+		baseDelay time.Duration
+		responses []opensearchtest.Response
+		wantCalls int
+		wantErr   bool
+	}{
+		{"backoff", time.Millisecond, []opensearchtest.Response{unavailable, ok}, 2, false},
+		// Com BaseDelay de uma hora só o Retry-After permite nova tentativa no prazo
+		{"retry-after", time.Hour, []opensearchtest.Response{retryNow, ok}, 2, false},
+		{"retry-after beyond deadline", time.Millisecond, []opensearchtest.Response{retryLater, ok}, 1, true},
+		{"not retryable", time.Millisecond, []opensearchtest.Response{badRequest, ok}, 1, true},
+		{"attempts exhausted", time.Millisecond, []opensearchtest.Response{unavailable}, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			for _, resp := range tt.responses {
+				srv.HandleResponse("GET", "/_cluster/health", resp)
+			}
+
+			client := srv.Client()
+			client.Retry = &opensearchmanager.RetryPolicy{MaxAttempts: 3, BaseDelay: tt.baseDelay}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, err := client.Do(ctx, "GET", "/_cluster/health", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls := len(srv.Requests()); calls != tt.wantCalls {
+				t.Errorf("got %d requests, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryTransportErrors(t *testing.T) {
+	tests := []struct {
+		method    string
+		wantCalls int
+	}{
+		{"GET", 3},
+		{"DELETE", 3},
+		// Um POST pode ter sido processado antes da conexão cair
+		{"POST", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+			}))
+			defer srv.Close()
+
+			client := opensearchmanager.NewClient(srv.URL, "admin", "admin")
+			client.Retry = &opensearchmanager.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+			if _, err := client.Do(context.Background(), tt.method, "/logs-1/_reindex", nil); err == nil {
+				t.Fatal("expected transport error")
+			}
+			if got := int(calls.Load()); got != tt.wantCalls {
+				t.Errorf("got %d requests, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestConnectionReuse(t *testing.T) {
+	var conns atomic.Int32
+	var health atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/_cluster/health":
+			// A primeira chamada recebe um 503 com corpo grande, descartado sem
+			// leitura pela RetryPolicy; corpos pequenos o transporte já descarta
+			if health.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte(`{"error":{"type":"unavailable","reason":"` + strings.Repeat("x", 1<<20) + `"},"status":503}`))
+				return
+			}
+			w.Write([]byte(`{"status":"green"}`))
+		case "/logs-1":
+			if r.Method != http.MethodHead {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"type":"index_not_found_exception","reason":"no such index"},"status":404}`))
+			}
+		case "/_cat/indices":
+			w.Write([]byte(`[{"index":"logs-1","status":"open"}]`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":{"type":"exception","reason":"` + strings.Repeat("y", 8192) + `"},"status":500}`))
+		}
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	client := opensearchmanager.NewClient(srv.URL, "admin", "admin")
+	client.Retry = &opensearchmanager.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+	ctx := context.Background()
+
+	if _, err := client.Do(ctx, "GET", "/_cluster/health", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(ctx, "GET", "/logs-1", nil); !opensearchmanager.IsIndexNotFound(err) {
+		t.Fatalf("got %v, want index not found", err)
+	}
+	if _, err := client.IndexExists(ctx, "logs-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListIndices(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.ListIndicesStream(ctx, func(opensearchmanager.IndexInfo) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(ctx, "POST", "/_boom", nil); err == nil {
+		t.Fatal("expected server error")
+	}
+
+	if got := conns.Load(); got != 1 {
+		t.Errorf("opened %d connections, want 1", got)
+	}
+}
+
+func TestGzipResponse(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(`[{"index":"logs-1","status":"open","docs.count":"7"}]`))
+	zw.Close()
+
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.HandleResponse("GET", "/_cat/indices", opensearchtest.Response{
+		StatusCode: 200,
+		Body:       buf.String(),
+		Header:     http.Header{"Content-Encoding": {"gzip"}},
+	})
+
+	indices, err := srv.Client().ListIndices(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices) != 1 || indices[0].Name != "logs-1" || indices[0].DocsCount != 7 {
+		t.Errorf("unexpected indices: %+v", indices)
+	}
+	if got := srv.Requests()[0].Header.Get("Accept-Encoding"); got != "gzip" {
+		t.Errorf("Accept-Encoding = %q, want gzip", got)
+	}
+}
+
+func TestEndpointPathPrefix(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/os/_cat/indices", 200, `[{"index":"logs-1","status":"open"}]`)
+
+	client := opensearchmanager.NewClient(srv.URL+"/os/", "admin", "admin")
+	indices, err := client.ListIndices(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices) != 1 || indices[0].Name != "logs-1" {
+		t.Errorf("unexpected indices: %+v", indices)
+	}
+	if path := srv.Requests()[0].Path; path != "/os/_cat/indices" {
+		t.Errorf("request path = %q, want /os/_cat/indices", path)
+	}
+}
+
+func TestAWSV4Signing(t *testing.T) {
+	srv := opensearchtest.NewServer()
+	defer srv.Close()
+	srv.Handle("GET", "/_cluster/health", 200, `{"status":"green"}`)
+
+	client := srv.Client()
+	client.Auth = opensearchmanager.NewAWSV4Authenticator("AKID", "SECRET", "us-east-1", "")
+	if _, err := client.Do(context.Background(), "GET", "/_cluster/health", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	req := srv.Requests()[0]
+	amzDate := req.Header.Get("X-Amz-Date")
+	if amzDate == "" {
+		t.Fatal("missing X-Amz-Date")
+	}
+	if req.Header.Get("Authorization") == "" || strings.HasPrefix(req.Header.Get("Authorization"), "Basic ") {
+		t.Fatalf("unexpected Authorization: %q", req.Header.Get("Authorization"))
+	}
+
+	// Recalcula a assinatura a partir do que o servidor recebeu
+	u, _ := url.Parse(srv.URL)
+	emptyHash := sha256Sum("")
+	if got := req.Header.Get("X-Amz-Content-Sha256"); got != emptyHash {
+		t.Errorf("X-Amz-Content-Sha256 = %q, want %q", got, emptyHash)
+	}
+	canonical := strings.Join([]string{
+		"GET",
+		"/_cluster/health",
+		"",
+		"host:" + u.Host + "\nx-amz-content-sha256:" + emptyHash + "\nx-amz-date:" + amzDate + "\n",
+		"host;x-amz-content-sha256;x-amz-date",
+		emptyHash,
+	}, "\n")
+	scope := amzDate[:8] + "/us-east-1/es/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Sum(canonical)
+
+	key := []byte("AWS4SECRET")
+	for _, part := range []string{amzDate[:8], "us-east-1", "es", "aws4_request"} {
+		key = hmacSum(key, part)
+	}
+	want := "AWS4-HMAC-SHA256 Credential=AKID/" + scope +
+		", SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=" + hex.EncodeToString(hmacSum(key, stringToSign))
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBearerTokenRefresh(t *testing.T) {
+	unauthorized := opensearchtest.Response{StatusCode: 401, Body: `{"error":"token expired"}`}
+	ok := opensearchtest.Response{StatusCode: 200, Body: `{"status":"green"}`}
+
+	tests := []struct {
+		name        string
+		responses   []opensearchtest.Response
+		refreshErr  error
+		wantCalls   int
+		wantRefresh int32
+		wantErr     bool
+		wantTokens  []string
+	}{
+		{"refreshed", []opensearchtest.Response{unauthorized, ok}, nil, 2, 1, false, []string{"Bearer old", "Bearer new"}},
+		// Um segundo 401 após o refresh é devolvido sem novo refresh
+		{"still unauthorized", []opensearchtest.Response{unauthorized}, nil, 2, 1, true, []string{"Bearer old", "Bearer new"}},
+		{"refresh fails", []opensearchtest.Response{unauthorized, ok}, errors.New("idp down"), 1, 1, true, []string{"Bearer old"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := opensearchtest.NewServer()
+			defer srv.Close()
+			for _, resp := range tt.responses {
+				srv.HandleResponse("GET", "/_cluster/health", resp)
+			}
+
+			var refreshes atomic.Int32
+			client := srv.Client()
+			client.Auth = opensearchmanager.NewBearerAuthenticator("old", func(context.Context) (string, error) {
+				refreshes.Add(1)
+				return "new", tt.refreshErr
+			})
+			// O reenvio após o refresh não consome tentativas da RetryPolicy
+			client.Retry = &opensearchmanager.RetryPolicy{MaxAttempts: 1}
+
+			_, err := client.Do(context.Background(), "GET", "/_cluster/health", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := refreshes.Load(); got != tt.wantRefresh {
+				t.Errorf("refreshed %d times, want %d", got, tt.wantRefresh)
+			}
+			var tokens []string
+			for _, req := range srv.Requests() {
+				tokens = append(tokens, req.Header.Get("Authorization"))
+			}
+			if len(tokens) != tt.wantCalls || !reflect.DeepEqual(tokens, tt.wantTokens) {
+				t.Errorf("Authorization headers %v, want %v", tokens, tt.wantTokens)
+			}
+		})
+	}
+}
+
+func sha256Sum(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSum(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,7 @@
+package opensearchmanager
+
+// Version é a versão da biblioteca, usada no User-Agent padrão
+const Version = "0.1.0"
+
+// DefaultUserAgent identifica as requisições feitas por esta biblioteca
+const DefaultUserAgent = "go-opensearch-curator/" + Version